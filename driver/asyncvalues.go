@@ -0,0 +1,109 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+
+	"github.com/labstack/echo/v4"
+	gometrics "github.com/rcrowley/go-metrics"
+)
+
+const (
+	readingsDelayedName = "ReadingsDelayed"
+	readingsDroppedName = "ReadingsDropped"
+
+	retryAfterHeader = "Retry-After"
+)
+
+var errAsyncValuesTimeout = errors.New("service is overloaded, readings were not accepted in time")
+
+// asyncValuesMetrics counts the readings which couldn't be handed to the SDK right away
+type asyncValuesMetrics struct {
+	readingsDelayed gometrics.Counter
+	readingsDropped gometrics.Counter
+}
+
+func newAsyncValuesMetrics() *asyncValuesMetrics {
+	return &asyncValuesMetrics{
+		readingsDelayed: gometrics.NewCounter(),
+		readingsDropped: gometrics.NewCounter(),
+	}
+}
+
+// register registers the counters with the SDK's metrics manager so they are
+// reported when enabled in the Writable.Telemetry configuration
+func (m *asyncValuesMetrics) register(handler RestHandler) error {
+	metricsManager := handler.service.MetricsManager()
+	if metricsManager == nil {
+		return errors.New("metrics manager not available")
+	}
+
+	if err := metricsManager.Register(readingsDelayedName, m.readingsDelayed, nil); err != nil {
+		return fmt.Errorf("unable to register metric %s: %s", readingsDelayedName, err.Error())
+	}
+	if err := metricsManager.Register(readingsDroppedName, m.readingsDropped, nil); err != nil {
+		return fmt.Errorf("unable to register metric %s: %s", readingsDroppedName, err.Error())
+	}
+
+	return nil
+}
+
+// sendAsyncValues hands the readings to the SDK. When the SDK doesn't accept them
+// right away the readings are counted as delayed and, if they still aren't accepted
+// within the configured timeout or the client went away, as dropped.
+func (handler RestHandler) sendAsyncValues(ctx context.Context, asyncValues *models.AsyncValues) error {
+	select {
+	case handler.asyncValues <- asyncValues:
+		return nil
+	default:
+	}
+
+	count := int64(len(asyncValues.CommandValues))
+	handler.metrics.readingsDelayed.Inc(count)
+
+	var timeout <-chan time.Time
+	if duration, _ := parseDuration(handler.config.AsyncValuesTimeout); duration > 0 {
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case handler.asyncValues <- asyncValues:
+		return nil
+	case <-timeout:
+		handler.metrics.readingsDropped.Inc(count)
+		return errAsyncValuesTimeout
+	case <-ctx.Done():
+		handler.metrics.readingsDropped.Inc(count)
+		return ctx.Err()
+	}
+}
+
+// overloaded responds 503 Service Unavailable asking the client to retry later. The
+// Retry-After header is omitted without RetryAfter, a zero delay would ask clients to
+// retry right away.
+func (handler RestHandler) overloaded(c echo.Context, body interface{}) error {
+	if retryAfter, _ := parseDuration(handler.config.RetryAfter); retryAfter > 0 {
+		c.Response().Header().Set(retryAfterHeader, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+
+	if body != nil {
+		return c.JSON(http.StatusServiceUnavailable, body)
+	}
+
+	return c.String(http.StatusServiceUnavailable, errAsyncValuesTimeout.Error())
+}
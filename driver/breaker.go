@@ -0,0 +1,293 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+)
+
+var errCircuitOpen = errors.New("circuit breaker is open, device is unreachable")
+
+// CircuitBreakerConfig holds the settings of the circuit breakers guarding the
+// commands sent to the end devices
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed commands after which the
+	// breaker opens and the device is set DOWN. Zero disables the circuit breakers
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before a probe is sent to the device
+	OpenTimeout string
+}
+
+// Validate ensures the circuit breaker configuration has proper values
+func (c CircuitBreakerConfig) Validate() error {
+	if c.FailureThreshold < 0 {
+		return errors.New("invalid FailureThreshold: must not be negative")
+	}
+	openTimeout, err := parseDuration(c.OpenTimeout)
+	if err != nil {
+		return fmt.Errorf("invalid OpenTimeout: %s", err.Error())
+	}
+	if c.FailureThreshold > 0 && openTimeout == 0 {
+		return errors.New("invalid OpenTimeout: must be a positive duration")
+	}
+
+	return nil
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breakerTransition is a change of the breaker which is reported as change of the
+// device's operating state
+type breakerTransition int
+
+const (
+	breakerUnchanged breakerTransition = iota
+	breakerTripped
+	breakerRecovered
+)
+
+// circuitBreaker tracks the consecutive failures of the commands sent to a device
+type circuitBreaker struct {
+	state    breakerState
+	failures int
+	openedAt time.Time
+	// generation is increased whenever the breaker trips, so that a probe of an
+	// earlier outage stops
+	generation int
+	// protocolParams are the latest protocol properties of the device, used for probing
+	protocolParams RestProtocolParams
+	done           chan struct{}
+	mutex          sync.Mutex
+}
+
+// allow checks whether a request may be sent to the device. Once the open timeout
+// elapsed a single request is let through as probe.
+func (b *circuitBreaker) allow(now time.Time, openTimeout time.Duration) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case breakerClosed:
+		return true
+	case breakerOpen:
+		if now.Sub(b.openedAt) < openTimeout {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	default:
+		// A probe is already in flight
+		return false
+	}
+}
+
+// record updates the breaker with the outcome of a request, returning the transition
+// and the generation of the breaker
+func (b *circuitBreaker) record(failed bool, threshold int, now time.Time) (breakerTransition, int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !failed {
+		b.failures = 0
+		if b.state == breakerClosed {
+			return breakerUnchanged, b.generation
+		}
+		b.state = breakerClosed
+		return breakerRecovered, b.generation
+	}
+
+	switch b.state {
+	case breakerClosed:
+		b.failures++
+		if b.failures < threshold {
+			return breakerUnchanged, b.generation
+		}
+		b.state = breakerOpen
+		b.openedAt = now
+		b.generation++
+		return breakerTripped, b.generation
+	case breakerHalfOpen:
+		// The probe failed, wait for another open timeout
+		b.state = breakerOpen
+		b.openedAt = now
+	}
+
+	return breakerUnchanged, b.generation
+}
+
+func (b *circuitBreaker) setProtocolParams(protocolParams RestProtocolParams) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.protocolParams = protocolParams
+}
+
+// probing returns the protocol properties to probe the device with, and false once
+// the outage of the generation is over
+func (b *circuitBreaker) probing(generation int) (RestProtocolParams, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.protocolParams, b.state != breakerClosed && b.generation == generation
+}
+
+// circuitBreakers holds the circuit breaker of each device
+type circuitBreakers struct {
+	breakers map[string]*circuitBreaker
+	mutex    sync.Mutex
+}
+
+func newCircuitBreakers() *circuitBreakers {
+	return &circuitBreakers{breakers: map[string]*circuitBreaker{}}
+}
+
+func (c *circuitBreakers) get(deviceName string) *circuitBreaker {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	breaker, ok := c.breakers[deviceName]
+	if !ok {
+		breaker = &circuitBreaker{done: make(chan struct{})}
+		c.breakers[deviceName] = breaker
+	}
+
+	return breaker
+}
+
+// update sets the protocol properties the breaker of the device probes with
+func (c *circuitBreakers) update(deviceName string, protocolParams RestProtocolParams) {
+	c.mutex.Lock()
+	breaker, ok := c.breakers[deviceName]
+	c.mutex.Unlock()
+
+	if ok {
+		breaker.setProtocolParams(protocolParams)
+	}
+}
+
+// remove stops probing the device and drops its breaker
+func (c *circuitBreakers) remove(deviceName string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if breaker, ok := c.breakers[deviceName]; ok {
+		close(breaker.done)
+		delete(c.breakers, deviceName)
+	}
+}
+
+// close stops probing all devices
+func (c *circuitBreakers) close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for deviceName, breaker := range c.breakers {
+		close(breaker.done)
+		delete(c.breakers, deviceName)
+	}
+}
+
+// sendRequest sends the request to the device through the device's circuit breaker.
+// While the breaker is open the request fails fast.
+func (driver *RestDriver) sendRequest(deviceName string, client *http.Client, request *http.Request, protocolParams RestProtocolParams) (*http.Response, error) {
+	config := driver.config.AppCustom.CircuitBreaker
+	if config.FailureThreshold == 0 {
+		return driver.doRequest(deviceName, client, request, driver.retryPolicy(protocolParams))
+	}
+
+	breaker := driver.breakers.get(deviceName)
+	breaker.setProtocolParams(protocolParams)
+	openTimeout, _ := parseDuration(config.OpenTimeout)
+	if !breaker.allow(time.Now(), openTimeout) {
+		return nil, errCircuitOpen
+	}
+
+	resp, err := driver.doRequest(deviceName, client, request, driver.retryPolicy(protocolParams))
+	driver.recordResult(deviceName, breaker, err != nil || resp.StatusCode >= http.StatusInternalServerError)
+
+	return resp, err
+}
+
+// recordResult updates the breaker of the device with the outcome of a request and
+// reports the device DOWN or UP as the breaker trips or recovers
+func (driver *RestDriver) recordResult(deviceName string, breaker *circuitBreaker, failed bool) {
+	config := driver.config.AppCustom.CircuitBreaker
+
+	transition, generation := breaker.record(failed, config.FailureThreshold, time.Now())
+	switch transition {
+	case breakerTripped:
+		driver.logger.Warnf("Device '%s' failed %d consecutive commands, circuit breaker opened", deviceName, config.FailureThreshold)
+		driver.updateOperatingState(deviceName, models.Down)
+		go driver.probe(deviceName, breaker, generation)
+	case breakerRecovered:
+		driver.logger.Infof("Device '%s' is reachable again, circuit breaker closed", deviceName)
+		driver.updateOperatingState(deviceName, models.Up)
+	}
+}
+
+func (driver *RestDriver) updateOperatingState(deviceName string, state models.OperatingState) {
+	if err := driver.sdk.UpdateDeviceOperatingState(deviceName, state); err != nil {
+		driver.logger.Errorf("Unable to set OperatingState of device '%s' to %s: %s", deviceName, state, err.Error())
+	}
+}
+
+// probe checks whether the device is reachable again after every open timeout,
+// until the breaker closes. Commands aren't sent to devices which are DOWN, so
+// without probing the device would never recover.
+func (driver *RestDriver) probe(deviceName string, breaker *circuitBreaker, generation int) {
+	openTimeout, _ := parseDuration(driver.config.AppCustom.CircuitBreaker.OpenTimeout)
+
+	for {
+		timer := time.NewTimer(openTimeout)
+		select {
+		case <-timer.C:
+		case <-breaker.done:
+			timer.Stop()
+			return
+		}
+
+		protocolParams, ok := breaker.probing(generation)
+		if !ok {
+			return
+		}
+		if !breaker.allow(time.Now(), openTimeout) {
+			continue
+		}
+
+		driver.recordResult(deviceName, breaker, !driver.reachable(deviceName, protocolParams))
+	}
+}
+
+// reachable sends a GET request to the base path of the device. Any response other
+// than a server error means the device is reachable.
+func (driver *RestDriver) reachable(deviceName string, protocolParams RestProtocolParams) bool {
+	client, err := driver.httpClient(deviceName, protocolParams)
+	if err != nil {
+		return false
+	}
+
+	resp, err := client.Get(protocolParams.pathURL(protocolParams.path))
+	if err != nil {
+		driver.logger.Debugf("Probe of device '%s' failed: %s", deviceName, err.Error())
+		return false
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedResponseSize))
+	_ = resp.Body.Close()
+
+	return resp.StatusCode < http.StatusInternalServerError
+}
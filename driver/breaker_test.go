@@ -0,0 +1,107 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("21.5"))
+	}))
+	defer server.Close()
+
+	resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	config := CustomConfig{CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: "50ms"}}
+	driver, service := newTestDriver(t, config, "device", resource)
+	defer driver.breakers.close()
+	down, up := models.OperatingState(models.Down), models.OperatingState(models.Up)
+	service.On("UpdateDeviceOperatingState", "device", down).Return(nil)
+	service.On("UpdateDeviceOperatingState", "device", up).Return(nil)
+	protocols := restProtocols(t, server, nil)
+	reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64}}
+
+	// The breaker opens after the second consecutive failure
+	for i := 0; i < 2; i++ {
+		_, err := driver.HandleReadCommands("device", protocols, reqs)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, errCircuitOpen)
+	}
+	service.AssertCalled(t, "UpdateDeviceOperatingState", "device", down)
+
+	// While open, commands fail fast without reaching the device
+	sent := requests.Load()
+	_, err := driver.HandleReadCommands("device", protocols, reqs)
+	assert.ErrorIs(t, err, errCircuitOpen)
+	assert.Equal(t, sent, requests.Load())
+
+	// Once the device is back, the probe closes the breaker
+	healthy.Store(true)
+	require.Eventually(t, func() bool {
+		_, err := driver.HandleReadCommands("device", protocols, reqs)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	service.AssertCalled(t, "UpdateDeviceOperatingState", "device", up)
+	service.AssertNumberOfCalls(t, "UpdateDeviceOperatingState", 2)
+}
+
+func TestReachable(t *testing.T) {
+	var path atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path.Store(r.URL.Path)
+	}))
+	defer server.Close()
+
+	driver, _ := newTestDriver(t, CustomConfig{}, "device")
+	for _, devicePath := range []string{"api", "/api"} {
+		protocolParams, err := getDeviceParameters(restProtocols(t, server, map[string]any{RESTPath: devicePath}))
+		require.NoError(t, err)
+		assert.True(t, driver.reachable("device", protocolParams))
+		assert.Equal(t, "/api", path.Load(), "the probe must request the device's path like commands")
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	breaker := &circuitBreaker{done: make(chan struct{})}
+	now := time.Now()
+
+	transition, _ := breaker.record(true, 1, now)
+	assert.Equal(t, breakerTripped, transition)
+	assert.False(t, breaker.allow(now, time.Second), "open breaker must fail fast")
+
+	later := now.Add(time.Second)
+	assert.True(t, breaker.allow(later, time.Second), "a probe is let through after the open timeout")
+	assert.False(t, breaker.allow(later, time.Second), "only a single probe is let through")
+
+	// A failed probe opens the breaker again without tripping it anew
+	transition, _ = breaker.record(true, 1, later)
+	assert.Equal(t, breakerUnchanged, transition)
+	assert.False(t, breaker.allow(later, time.Second))
+
+	assert.True(t, breaker.allow(later.Add(time.Second), time.Second))
+	transition, _ = breaker.record(false, 1, later.Add(time.Second))
+	assert.Equal(t, breakerRecovered, transition)
+	assert.True(t, breaker.allow(later.Add(time.Second), time.Second))
+}
@@ -0,0 +1,140 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"fmt"
+	"time"
+)
+
+// CustomConfigSectionName is the name of the custom configuration section
+const CustomConfigSectionName = "AppCustom"
+
+// ServiceConfig holds the custom configuration of the device service
+type ServiceConfig struct {
+	AppCustom CustomConfig
+}
+
+// UpdateFromRaw updates the service's full configuration from raw data received from
+// the Service Provider.
+func (sc *ServiceConfig) UpdateFromRaw(rawConfig interface{}) bool {
+	configuration, ok := rawConfig.(*ServiceConfig)
+	if !ok {
+		return false
+	}
+
+	*sc = *configuration
+
+	return true
+}
+
+// CustomConfig holds the settings of the REST device service
+type CustomConfig struct {
+	// MaxOriginSkew is how far a client supplied reading origin may be ahead of
+	// the service clock before the reading is rejected
+	MaxOriginSkew string
+	// MaxOriginAge is how far a client supplied reading origin may be behind the
+	// service clock before the reading is rejected. Empty or zero means no limit
+	MaxOriginAge string
+	// AsyncValuesTimeout is how long an incoming request waits for the SDK to accept
+	// its readings before it is rejected as overloaded. Empty or zero means no limit
+	AsyncValuesTimeout string
+	// RetryAfter is the delay clients are asked to wait when the service is overloaded
+	RetryAfter string
+	// IngestionAuth selects how clients posting readings authenticate, either
+	// with an EdgeX issued JWT (EdgeX), with credentials of the device (DeviceKey) or
+	// by signing the request with the device's secret (HMAC)
+	IngestionAuth string
+	// HMAC holds the settings for verifying signed requests
+	HMAC HMACConfig
+	// AutoProvision holds the settings for creating unknown devices on their first POST
+	AutoProvision AutoProvisionConfig
+	// HTTPClient holds the settings of the HTTP clients sending commands to the end devices
+	HTTPClient HTTPClientConfig
+	// Retry holds the retry policy of the commands sent to the end devices
+	Retry RetryConfig
+	// CircuitBreaker holds the settings of the per device circuit breakers
+	CircuitBreaker CircuitBreakerConfig
+	// OAuth2 holds the settings of the OAuth2 access tokens of the end devices
+	OAuth2 OAuth2Config
+	// MaxBinaryWriteSize is the maximum size in bytes of Binary values written to the
+	// end devices. Zero means no limit
+	MaxBinaryWriteSize int64
+	// MaxConcurrentReads is how many resources of a device are read at the same
+	// time. Zero reads them one after another
+	MaxConcurrentReads int
+}
+
+// Validate ensures the custom configuration has proper values
+func (c CustomConfig) Validate() error {
+	if _, err := parseDuration(c.MaxOriginSkew); err != nil {
+		return fmt.Errorf("invalid MaxOriginSkew: %s", err.Error())
+	}
+	if _, err := parseDuration(c.MaxOriginAge); err != nil {
+		return fmt.Errorf("invalid MaxOriginAge: %s", err.Error())
+	}
+	if _, err := parseDuration(c.AsyncValuesTimeout); err != nil {
+		return fmt.Errorf("invalid AsyncValuesTimeout: %s", err.Error())
+	}
+	if _, err := parseDuration(c.RetryAfter); err != nil {
+		return fmt.Errorf("invalid RetryAfter: %s", err.Error())
+	}
+	switch c.IngestionAuth {
+	case "", IngestionAuthEdgeX, IngestionAuthDeviceKey:
+	case IngestionAuthHMAC:
+		if err := c.HMAC.Validate(); err != nil {
+			return fmt.Errorf("invalid HMAC: %s", err.Error())
+		}
+	default:
+		return fmt.Errorf("invalid IngestionAuth '%s', must be %s, %s or %s", c.IngestionAuth, IngestionAuthEdgeX, IngestionAuthDeviceKey, IngestionAuthHMAC)
+	}
+	if err := c.AutoProvision.Validate(); err != nil {
+		return fmt.Errorf("invalid AutoProvision: %s", err.Error())
+	}
+	// Device keys and HMAC secrets are looked up from the device, unknown devices
+	// would be rejected before they could be provisioned
+	if c.AutoProvision.Enabled && (c.IngestionAuth == IngestionAuthDeviceKey || c.IngestionAuth == IngestionAuthHMAC) {
+		return fmt.Errorf("invalid AutoProvision: can't be enabled with IngestionAuth %s", c.IngestionAuth)
+	}
+	if err := c.HTTPClient.Validate(); err != nil {
+		return fmt.Errorf("invalid HTTPClient: %s", err.Error())
+	}
+	if err := c.Retry.Validate(); err != nil {
+		return fmt.Errorf("invalid Retry: %s", err.Error())
+	}
+	if err := c.CircuitBreaker.Validate(); err != nil {
+		return fmt.Errorf("invalid CircuitBreaker: %s", err.Error())
+	}
+	if err := c.OAuth2.Validate(); err != nil {
+		return fmt.Errorf("invalid OAuth2: %s", err.Error())
+	}
+	if c.MaxBinaryWriteSize < 0 {
+		return fmt.Errorf("invalid MaxBinaryWriteSize: %d must not be negative", c.MaxBinaryWriteSize)
+	}
+	if c.MaxConcurrentReads < 0 {
+		return fmt.Errorf("invalid MaxConcurrentReads: %d must not be negative", c.MaxConcurrentReads)
+	}
+
+	return nil
+}
+
+// parseDuration parses a duration setting, an empty setting is a zero duration
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("duration '%s' must not be negative", value)
+	}
+
+	return duration, nil
+}
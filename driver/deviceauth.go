@@ -0,0 +1,142 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// IngestionAuthEdgeX requires an EdgeX issued JWT to post readings
+	IngestionAuthEdgeX = "EdgeX"
+	// IngestionAuthDeviceKey requires the API key or bearer token of the device
+	// the readings are posted for
+	IngestionAuthDeviceKey = "DeviceKey"
+
+	apiKeyHeader = "X-API-Key"
+	bearerPrefix = "Bearer "
+
+	// Keys of the device's ingestion secret holding the accepted credentials
+	secretKeyAPIKey = "apiKey"
+	secretKeyToken  = "token"
+)
+
+var errUnauthorized = errors.New("missing or invalid device credentials")
+
+// ingestionSecretName returns the name of the secret holding the device's
+// credentials, taken from the first protocol in name order which defines it
+func ingestionSecretName(protocols map[string]models.ProtocolProperties) string {
+	names := make([]string, 0, len(protocols))
+	for name := range protocols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if secretName, ok := protocols[name][IngestionSecretName]; ok {
+			return fmt.Sprint(secretName)
+		}
+	}
+
+	return ""
+}
+
+// authorizeDevice checks the request carries valid credentials of the device,
+// depending on the configured ingestion authentication
+func (handler RestHandler) authorizeDevice(deviceName string, request *http.Request) error {
+	switch handler.config.IngestionAuth {
+	case IngestionAuthDeviceKey:
+		return handler.verifyDeviceKey(deviceName, request)
+	case IngestionAuthHMAC:
+		if err := handler.verifySignature(deviceName, request); err != nil {
+			handler.logger.Errorf("Invalid signature for device '%s': %s", deviceName, err.Error())
+			if errors.Is(err, errRequestTooLarge) {
+				return err
+			}
+			return errUnauthorized
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// verifyDeviceKey checks the API key or bearer token presented with the request
+// against the credentials of the device
+func (handler RestHandler) verifyDeviceKey(deviceName string, request *http.Request) error {
+	secretKey := secretKeyAPIKey
+	credential := request.Header.Get(apiKeyHeader)
+	if credential == "" {
+		authorization := request.Header.Get(echo.HeaderAuthorization)
+		if !strings.HasPrefix(authorization, bearerPrefix) {
+			return errUnauthorized
+		}
+		secretKey = secretKeyToken
+		credential = strings.TrimPrefix(authorization, bearerPrefix)
+	}
+	if credential == "" {
+		return errUnauthorized
+	}
+
+	expected, err := handler.deviceSecret(deviceName, secretKey)
+	if err != nil || subtle.ConstantTimeCompare([]byte(expected), []byte(credential)) != 1 {
+		return errUnauthorized
+	}
+
+	return nil
+}
+
+// deviceSecret returns a value of the device's ingestion secret. The secret is read
+// from the secret store on every request so that rotated credentials apply without
+// restart.
+func (handler RestHandler) deviceSecret(deviceName string, key string) (string, error) {
+	device, err := handler.service.GetDeviceByName(deviceName)
+	if err != nil {
+		return "", errUnauthorized
+	}
+
+	secretName := ingestionSecretName(device.Protocols)
+	if secretName == "" {
+		handler.logger.Errorf("Device '%s' has no %s protocol property", deviceName, IngestionSecretName)
+		return "", errUnauthorized
+	}
+
+	secrets, err := handler.service.SecretProvider().GetSecret(secretName)
+	if err != nil {
+		handler.logger.Errorf("Unable to get secret '%s' of device '%s': %s", secretName, deviceName, err.Error())
+		return "", errUnauthorized
+	}
+
+	value := secrets[key]
+	if value == "" {
+		handler.logger.Errorf("Secret '%s' of device '%s' has no %s", secretName, deviceName, key)
+		return "", errUnauthorized
+	}
+
+	return value, nil
+}
+
+// unauthorized responds 401 Unauthorized, or 413 Request Entity Too Large when the
+// body of a signed request is too large to verify
+func (handler RestHandler) unauthorized(c echo.Context, err error) error {
+	if errors.Is(err, errRequestTooLarge) {
+		return c.String(http.StatusRequestEntityTooLarge, err.Error())
+	}
+	if handler.config.IngestionAuth == IngestionAuthDeviceKey {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+	}
+	return c.String(http.StatusUnauthorized, errUnauthorized.Error())
+}
@@ -0,0 +1,87 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces/mocks"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthorizeDevice(t *testing.T) {
+	secretProvider := &bootstrapMocks.SecretProvider{}
+	secretProvider.On("GetSecret", "sensorA-key").Return(map[string]string{secretKeyAPIKey: "keyA"}, nil)
+	secretProvider.On("GetSecret", "sensorB-key").Return(map[string]string{secretKeyToken: "tokenB"}, nil)
+
+	service := &mocks.DeviceServiceSDK{}
+	service.On("LoggingClient").Return(logger.NewMockClient())
+	service.On("AsyncValuesChannel").Return(make(chan *sdkModels.AsyncValues))
+	service.On("SecretProvider").Return(secretProvider)
+	service.On("GetDeviceByName", "sensorA").Return(models.Device{Name: "sensorA", Protocols: map[string]models.ProtocolProperties{
+		"other": {IngestionSecretName: "sensorA-key"},
+	}}, nil)
+	service.On("GetDeviceByName", "sensorB").Return(models.Device{Name: "sensorB", Protocols: map[string]models.ProtocolProperties{
+		"other": {IngestionSecretName: "sensorB-key"},
+	}}, nil)
+	service.On("GetDeviceByName", "sensorC").Return(models.Device{Name: "sensorC", Protocols: map[string]models.ProtocolProperties{
+		"other": {},
+	}}, nil)
+	service.On("GetDeviceByName", mock.Anything).Return(models.Device{}, errors.New("not found"))
+	authHandler := NewRestHandler(service, CustomConfig{IngestionAuth: IngestionAuthDeviceKey})
+
+	tests := []struct {
+		Name          string
+		DeviceName    string
+		APIKey        string
+		Authorization string
+		Authorized    bool
+	}{
+		{"Valid API key", "sensorA", "keyA", "", true},
+		{"Valid bearer token", "sensorB", "", "Bearer tokenB", true},
+		{"Key of another device", "sensorB", "keyA", "", false},
+		{"Token of another device", "sensorA", "", "Bearer tokenB", false},
+		{"Token used as API key", "sensorB", "tokenB", "", false},
+		{"Wrong API key", "sensorA", "keyB", "", false},
+		{"Basic authorization", "sensorB", "", "Basic dG9rZW5C", false},
+		{"No credentials", "sensorA", "", "", false},
+		{"Device without secret", "sensorC", "keyA", "", false},
+		{"Unknown device", "sensorD", "keyA", "", false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.Name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/api/v3/resource/"+testCase.DeviceName, nil)
+			if testCase.APIKey != "" {
+				request.Header.Set(apiKeyHeader, testCase.APIKey)
+			}
+			if testCase.Authorization != "" {
+				request.Header.Set("Authorization", testCase.Authorization)
+			}
+
+			err := authHandler.authorizeDevice(testCase.DeviceName, request)
+			if testCase.Authorized {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, errUnauthorized)
+			}
+		})
+	}
+}
+
+func TestAuthorizeDeviceDisabled(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "/api/v3/resource/sensorA", nil)
+	assert.NoError(t, handler.authorizeDevice("sensorA", request))
+}
@@ -0,0 +1,247 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"crypto/md5" //nolint:gosec // required by devices only supporting Digest with MD5
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// AuthMethodDigest answers the device's HTTP Digest challenge (RFC 7616)
+	AuthMethodDigest = "Digest"
+
+	digestPrefix = "Digest "
+	digestQOP    = "auth"
+
+	digestAlgorithmMD5        = "MD5"
+	digestAlgorithmMD5Sess    = "MD5-sess"
+	digestAlgorithmSHA256     = "SHA-256"
+	digestAlgorithmSHA256Sess = "SHA-256-sess"
+)
+
+// digestAlgorithms are the supported algorithms, strongest first
+var digestAlgorithms = []string{digestAlgorithmSHA256, digestAlgorithmSHA256Sess, digestAlgorithmMD5, digestAlgorithmMD5Sess}
+
+// digestChallenge is the latest challenge of a device, its nonce is reused for later
+// requests until the device issues a new one
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	// qop is empty for devices only supporting RFC 2069
+	qop string
+	// nonceCount counts the requests sent with the nonce
+	nonceCount uint32
+}
+
+// digestSessions holds the latest Digest challenge of each device
+type digestSessions struct {
+	challenges map[string]*digestChallenge
+	mutex      sync.Mutex
+}
+
+func newDigestSessions() *digestSessions {
+	return &digestSessions{challenges: map[string]*digestChallenge{}}
+}
+
+// set replaces the challenge of the device
+func (s *digestSessions) set(deviceName string, challenge *digestChallenge) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.challenges[deviceName] = challenge
+}
+
+// next returns the challenge of the device with the nonce count of the next request,
+// false when no challenge was received yet
+func (s *digestSessions) next(deviceName string) (digestChallenge, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	challenge, ok := s.challenges[deviceName]
+	if !ok {
+		return digestChallenge{}, false
+	}
+	challenge.nonceCount++
+
+	return *challenge, true
+}
+
+func (s *digestSessions) remove(deviceName string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.challenges, deviceName)
+}
+
+// parseDigestChallenge selects the strongest supported Digest challenge of the
+// WWW-Authenticate headers
+func parseDigestChallenge(header http.Header) (*digestChallenge, error) {
+	var selected *digestChallenge
+	for _, value := range header.Values(echo.HeaderWWWAuthenticate) {
+		if len(value) < len(digestPrefix) || !strings.EqualFold(value[:len(digestPrefix)], digestPrefix) {
+			continue
+		}
+		params := parseAuthParams(value[len(digestPrefix):])
+
+		challenge := &digestChallenge{
+			realm:     params["realm"],
+			nonce:     params["nonce"],
+			opaque:    params["opaque"],
+			algorithm: digestAlgorithmMD5,
+		}
+		if challenge.nonce == "" {
+			continue
+		}
+		if algorithm, ok := params["algorithm"]; ok {
+			index := slices.IndexFunc(digestAlgorithms, func(supported string) bool { return strings.EqualFold(supported, algorithm) })
+			if index < 0 {
+				continue
+			}
+			challenge.algorithm = digestAlgorithms[index]
+		}
+		if qop, ok := params["qop"]; ok {
+			if !slices.Contains(strings.Split(strings.ReplaceAll(qop, " ", ""), ","), digestQOP) {
+				continue
+			}
+			challenge.qop = digestQOP
+		}
+
+		if selected == nil || slices.Index(digestAlgorithms, challenge.algorithm) < slices.Index(digestAlgorithms, selected.algorithm) {
+			selected = challenge
+		}
+	}
+
+	if selected == nil {
+		return nil, errors.New("no supported Digest challenge, only qop=auth with MD5 or SHA-256 is supported")
+	}
+
+	return selected, nil
+}
+
+// parseAuthParams parses the comma separated auth-params of a challenge, values may
+// be quoted strings
+func parseAuthParams(value string) map[string]string {
+	params := map[string]string{}
+	for value != "" {
+		value = strings.TrimLeft(value, " \t,")
+		name, rest, found := strings.Cut(value, "=")
+		if !found {
+			break
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		rest = strings.TrimLeft(rest, " \t")
+
+		var param strings.Builder
+		if strings.HasPrefix(rest, `"`) {
+			i := 1
+			for ; i < len(rest) && rest[i] != '"'; i++ {
+				if rest[i] == '\\' && i+1 < len(rest) {
+					i++
+				}
+				param.WriteByte(rest[i])
+			}
+			value = rest[min(i+1, len(rest)):]
+		} else {
+			token, remaining, _ := strings.Cut(rest, ",")
+			param.WriteString(strings.TrimSpace(token))
+			value = remaining
+		}
+		params[name] = param.String()
+	}
+
+	return params
+}
+
+// digestAuthorization computes the Authorization header answering the challenge
+func digestAuthorization(challenge digestChallenge, username string, password string, method string, uri string) (string, error) {
+	cnonceBytes := make([]byte, 16)
+	if _, err := rand.Read(cnonceBytes); err != nil {
+		return "", fmt.Errorf("unable to generate cnonce: %v", err)
+	}
+	cnonce := hex.EncodeToString(cnonceBytes)
+	nonceCount := fmt.Sprintf("%08x", challenge.nonceCount)
+
+	params := []string{
+		fmt.Sprintf(`username="%s"`, quoteEscape(username)),
+		fmt.Sprintf(`realm="%s"`, quoteEscape(challenge.realm)),
+		fmt.Sprintf(`nonce="%s"`, quoteEscape(challenge.nonce)),
+		fmt.Sprintf(`uri="%s"`, quoteEscape(uri)),
+		fmt.Sprintf(`algorithm=%s`, challenge.algorithm),
+		fmt.Sprintf(`response="%s"`, digestResponse(challenge, username, password, method, uri, cnonce)),
+	}
+	if challenge.qop != "" {
+		params = append(params, fmt.Sprintf("qop=%s", challenge.qop), fmt.Sprintf("nc=%s", nonceCount), fmt.Sprintf(`cnonce="%s"`, cnonce))
+	}
+	if challenge.opaque != "" {
+		params = append(params, fmt.Sprintf(`opaque="%s"`, quoteEscape(challenge.opaque)))
+	}
+
+	return digestPrefix + strings.Join(params, ", "), nil
+}
+
+// digestResponse computes the response of RFC 7616 section 3.4.1
+func digestResponse(challenge digestChallenge, username string, password string, method string, uri string, cnonce string) string {
+	var newHash func() hash.Hash = md5.New
+	if strings.HasPrefix(challenge.algorithm, digestAlgorithmSHA256) {
+		newHash = sha256.New
+	}
+	digest := func(values ...string) string {
+		h := newHash()
+		h.Write([]byte(strings.Join(values, ":")))
+		return hex.EncodeToString(h.Sum(nil))
+	}
+
+	ha1 := digest(username, challenge.realm, password)
+	if strings.HasSuffix(challenge.algorithm, "-sess") {
+		ha1 = digest(ha1, challenge.nonce, cnonce)
+	}
+	ha2 := digest(method, uri)
+
+	if challenge.qop == "" {
+		return digest(ha1, challenge.nonce, ha2)
+	}
+
+	return digest(ha1, challenge.nonce, fmt.Sprintf("%08x", challenge.nonceCount), cnonce, challenge.qop, ha2)
+}
+
+func quoteEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+// authenticateDigest answers the latest challenge of the device. Before the first
+// challenge the request is sent without credentials to obtain one.
+func (driver *RestDriver) authenticateDigest(deviceName string, request *http.Request, protocolParams RestProtocolParams) error {
+	challenge, ok := driver.digests.next(deviceName)
+	if !ok {
+		return nil
+	}
+
+	secrets, err := driver.authSecret(protocolParams, secretKeyUsername, secretKeyPassword)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := digestAuthorization(challenge, secrets[secretKeyUsername], secrets[secretKeyPassword], request.Method, request.URL.RequestURI())
+	if err != nil {
+		return err
+	}
+	request.Header.Set(echo.HeaderAuthorization, authorization)
+
+	return nil
+}
@@ -0,0 +1,113 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestResponse(t *testing.T) {
+	// Example of RFC 7616 section 3.9.1
+	challenge := digestChallenge{
+		realm:      "http-auth@example.org",
+		nonce:      "7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v",
+		qop:        digestQOP,
+		nonceCount: 1,
+	}
+	cnonce := "f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ"
+
+	challenge.algorithm = digestAlgorithmMD5
+	assert.Equal(t, "8ca523f5e9506fed4657c9700eebdbec", digestResponse(challenge, "Mufasa", "Circle of Life", http.MethodGet, "/dir/index.html", cnonce))
+	challenge.algorithm = digestAlgorithmSHA256
+	assert.Equal(t, "753927fa0e85d155564e2e272a28d1802ca10daf4496794697cf8db5856cb6c1", digestResponse(challenge, "Mufasa", "Circle of Life", http.MethodGet, "/dir/index.html", cnonce))
+}
+
+func TestParseDigestChallenge(t *testing.T) {
+	header := http.Header{}
+	header.Add("WWW-Authenticate", `Digest realm="http-auth@example.org", qop="auth, auth-int", algorithm=MD5, nonce="7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", opaque="FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"`)
+	header.Add("WWW-Authenticate", `Digest realm="http-auth@example.org", qop="auth", algorithm=SHA-256, nonce="7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", opaque="FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"`)
+	header.Add("WWW-Authenticate", `Basic realm="http-auth@example.org"`)
+
+	challenge, err := parseDigestChallenge(header)
+	require.NoError(t, err)
+	assert.Equal(t, digestAlgorithmSHA256, challenge.algorithm, "the strongest algorithm must be selected")
+	assert.Equal(t, "http-auth@example.org", challenge.realm)
+	assert.Equal(t, "FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS", challenge.opaque)
+	assert.Equal(t, digestQOP, challenge.qop)
+
+	unsupported := http.Header{}
+	unsupported.Add("WWW-Authenticate", `Digest realm="r", qop="auth-int", nonce="n"`)
+	unsupported.Add("WWW-Authenticate", `Digest realm="r", algorithm=SHA-512-256, nonce="n"`)
+	_, err = parseDigestChallenge(unsupported)
+	assert.Error(t, err)
+
+	assert.Equal(t, map[string]string{"realm": `a "quoted", realm`, "nonce": "n", "stale": "true"},
+		parseAuthParams(`realm="a \"quoted\", realm", nonce=n, stale=true`))
+}
+
+func TestDigestAuth(t *testing.T) {
+	var mutex sync.Mutex
+	var challenges int
+	var nonceCounts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		authorization := r.Header.Get("Authorization")
+		params := parseAuthParams(strings.TrimPrefix(authorization, digestPrefix))
+		challenge := digestChallenge{realm: "device", nonce: "nonce-1", algorithm: digestAlgorithmSHA256, qop: digestQOP}
+		_, _ = fmt.Sscanf(params["nc"], "%08x", &challenge.nonceCount)
+		if params["nonce"] != challenge.nonce ||
+			params["response"] != digestResponse(challenge, "admin", "s3cr3t", r.Method, params["uri"], params["cnonce"]) {
+			challenges++
+			w.Header().Set("WWW-Authenticate", `Digest realm="device", qop="auth", algorithm=SHA-256, nonce="nonce-1"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		nonceCounts = append(nonceCounts, params["nc"])
+		_, _ = w.Write([]byte("21.5"))
+	}))
+	defer server.Close()
+
+	resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	driver, service := newTestDriver(t, CustomConfig{}, "device", resource)
+	secretProvider := &bootstrapMocks.SecretProvider{}
+	secretProvider.On("GetSecret", "device-auth").Return(map[string]string{secretKeyUsername: "admin", secretKeyPassword: "s3cr3t"}, nil)
+	secretProvider.On("GetSecret", "wrong-auth").Return(map[string]string{secretKeyUsername: "admin", secretKeyPassword: "wrong"}, nil)
+	service.On("SecretProvider").Return(secretProvider)
+	protocols := restProtocols(t, server, map[string]any{AuthMethod: AuthMethodDigest, AuthSecretName: "device-auth"})
+	reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64}}
+	value, err := sdkModels.NewCommandValue(resource.Name, common.ValueTypeFloat64, 21.5)
+	require.NoError(t, err)
+
+	responses, err := driver.HandleReadCommands("device", protocols, reqs)
+	require.NoError(t, err)
+	assert.Equal(t, 21.5, responses[0].Value)
+	require.NoError(t, driver.HandleWriteCommands("device", protocols, reqs, []*sdkModels.CommandValue{value}))
+	_, err = driver.HandleReadCommands("device", protocols, reqs)
+	require.NoError(t, err)
+
+	// The nonce of the first challenge is reused with increasing nonce count
+	assert.Equal(t, 1, challenges)
+	assert.Equal(t, []string{"00000001", "00000002", "00000003"}, nonceCounts)
+
+	protocols[RESTProtocol][AuthSecretName] = "wrong-auth"
+	_, err = driver.HandleReadCommands("device", protocols, reqs)
+	assert.Error(t, err)
+}
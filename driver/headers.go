@@ -0,0 +1,105 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+)
+
+// headerSecretPrefix marks header values read from the secret store, the value
+// secret:<secretName>/<key> is replaced by the key of the secret
+const headerSecretPrefix = "secret:"
+
+// parseHeaders reads a header map given as object or as JSON object string. The
+// header names are canonicalized so that maps merge regardless of their case.
+func parseHeaders(name string, value any) (map[string]string, error) {
+	var headers map[string]any
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		headers = value
+	case string:
+		if strings.TrimSpace(value) == "" {
+			return nil, nil
+		}
+		if err := json.Unmarshal([]byte(value), &headers); err != nil {
+			return nil, fmt.Errorf("invalid %s, must be a JSON object: %v", name, err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid %s, must be an object of header names and values", name)
+	}
+
+	parsed := make(map[string]string, len(headers))
+	for header, headerValue := range headers {
+		if header == "" || strings.ContainsAny(header, " \t\r\n:") {
+			return nil, fmt.Errorf("invalid %s, '%s' is not a valid header name", name, header)
+		}
+		switch headerValue.(type) {
+		case map[string]any, []any, nil:
+			return nil, fmt.Errorf("invalid %s, value of '%s' must be a string", name, header)
+		}
+		parsed[http.CanonicalHeaderKey(header)] = fmt.Sprint(headerValue)
+	}
+
+	return parsed, nil
+}
+
+// parseSecretReference splits a secret:<secretName>/<key> header value, false when
+// the value isn't read from the secret store
+func parseSecretReference(value string) (secretName string, key string, ok bool, err error) {
+	reference, ok := strings.CutPrefix(value, headerSecretPrefix)
+	if !ok {
+		return "", "", false, nil
+	}
+
+	index := strings.LastIndex(reference, "/")
+	if index <= 0 || index == len(reference)-1 {
+		return "", "", true, fmt.Errorf("invalid secret reference '%s', must be %s<secretName>/<key>", value, headerSecretPrefix)
+	}
+
+	return reference[:index], reference[index+1:], true, nil
+}
+
+// setCustomHeaders adds the headers of the device and of the resource to the request,
+// the resource's headers replace the device's headers of the same name. Values read
+// from the secret store are resolved for every request so that rotated secrets apply
+// without restart.
+func (driver *RestDriver) setCustomHeaders(request *http.Request, protocolParams RestProtocolParams, attributes map[string]any) error {
+	resourceHeaders, err := parseHeaders(RequestHeaders, attributes[RequestHeaders])
+	if err != nil {
+		return err
+	}
+	headers := maps.Clone(protocolParams.headers)
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	maps.Copy(headers, resourceHeaders)
+
+	for name, value := range headers {
+		secretName, key, ok, err := parseSecretReference(value)
+		if err != nil {
+			return fmt.Errorf("invalid header %s: %s", name, err.Error())
+		}
+		if ok {
+			secrets, err := driver.sdk.SecretProvider().GetSecret(secretName)
+			if err != nil {
+				return fmt.Errorf("unable to get secret '%s' of header %s: %s", secretName, name, err.Error())
+			}
+			if value, ok = secrets[key]; !ok {
+				return fmt.Errorf("secret '%s' of header %s has no %s", secretName, name, key)
+			}
+		}
+		request.Header.Set(name, value)
+	}
+
+	return nil
+}
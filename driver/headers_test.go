@@ -0,0 +1,118 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeaders(t *testing.T) {
+	tests := []struct {
+		name            string
+		value           any
+		expectedHeaders map[string]string
+		expectedError   bool
+	}{
+		{"not set", nil, nil, false},
+		{"empty string", "", nil, false},
+		{"JSON object", `{"accept":"application/json","X-Api-Version":2}`, map[string]string{"Accept": "application/json", "X-Api-Version": "2"}, false},
+		{"object", map[string]any{"x-tenant-id": "acme"}, map[string]string{"X-Tenant-Id": "acme"}, false},
+		{"invalid JSON", `{"Accept"`, nil, true},
+		{"JSON array", `["Accept"]`, nil, true},
+		{"invalid name", map[string]any{"X Tenant": "acme"}, nil, true},
+		{"object value", map[string]any{"Accept": map[string]any{"type": "json"}}, nil, true},
+		{"unsupported type", 42, nil, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			headers, err := parseHeaders(RESTHeaders, testCase.value)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedHeaders, headers)
+		})
+	}
+}
+
+func TestCustomHeaders(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		_, _ = w.Write([]byte("21.5"))
+	}))
+	defer server.Close()
+
+	resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	driver, service := newTestDriver(t, CustomConfig{}, "device", resource)
+	secretProvider := &bootstrapMocks.SecretProvider{}
+	secretProvider.On("GetSecret", "tenant").Return(map[string]string{"key": "t3nant"}, nil)
+	secretProvider.On("GetSecret", "device-auth").Return(map[string]string{secretKeyToken: "t0ken"}, nil)
+	secretProvider.On("GetSecret", "missing").Return(nil, errors.New("not found"))
+	service.On("SecretProvider").Return(secretProvider)
+	value, err := sdkModels.NewCommandValue(resource.Name, common.ValueTypeFloat64, 21.5)
+	require.NoError(t, err)
+
+	deviceHeaders := `{"Accept":"application/json","X-Tenant-Id":"acme","X-Api-Version":"1"}`
+	tests := []struct {
+		name            string
+		properties      map[string]any
+		attributes      map[string]any
+		expectedHeaders map[string]string
+		expectedError   bool
+	}{
+		{"device headers", map[string]any{RESTHeaders: deviceHeaders}, nil,
+			map[string]string{"Accept": "application/json", "X-Tenant-Id": "acme", "X-Api-Version": "1"}, false},
+		{"resource headers", nil, map[string]any{RequestHeaders: map[string]any{"X-Api-Version": "2"}},
+			map[string]string{"X-Api-Version": "2"}, false},
+		{"resource wins", map[string]any{RESTHeaders: deviceHeaders}, map[string]any{RequestHeaders: map[string]any{"x-api-version": "2"}},
+			map[string]string{"Accept": "application/json", "X-Tenant-Id": "acme", "X-Api-Version": "2"}, false},
+		{"secret value", map[string]any{RESTHeaders: `{"X-Tenant-Key":"secret:tenant/key"}`}, nil,
+			map[string]string{"X-Tenant-Key": "t3nant"}, false},
+		{"authentication wins", map[string]any{RESTHeaders: `{"Authorization":"Bearer other"}`, AuthMethod: AuthMethodBearer, AuthSecretName: "device-auth"}, nil,
+			map[string]string{"Authorization": "Bearer t0ken"}, false},
+		{"secret not found", map[string]any{RESTHeaders: `{"X-Tenant-Key":"secret:missing/key"}`}, nil, nil, true},
+		{"key not in secret", map[string]any{RESTHeaders: `{"X-Tenant-Key":"secret:tenant/other"}`}, nil, nil, true},
+		{"invalid secret reference", nil, map[string]any{RequestHeaders: map[string]any{"X-Tenant-Key": "secret:tenant"}}, nil, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			protocols := restProtocols(t, server, testCase.properties)
+			reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64, Attributes: testCase.attributes}}
+
+			header = nil
+			_, err := driver.HandleReadCommands("device", protocols, reqs)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, header, "request must not be sent without its headers")
+				return
+			}
+			require.NoError(t, err)
+			for name, expected := range testCase.expectedHeaders {
+				assert.Equal(t, expected, header.Get(name), name)
+			}
+
+			header = nil
+			err = driver.HandleWriteCommands("device", protocols, reqs, []*sdkModels.CommandValue{value})
+			require.NoError(t, err)
+			for name, expected := range testCase.expectedHeaders {
+				assert.Equal(t, expected, header.Get(name), name)
+			}
+		})
+	}
+}
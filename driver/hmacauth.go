@@ -0,0 +1,262 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // required by webhook providers signing with HMAC-SHA1
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// IngestionAuthHMAC requires requests to be signed with the HMAC secret of the
+	// device the readings are posted for
+	IngestionAuthHMAC = "HMAC"
+
+	// Key of the device's ingestion secret holding the HMAC secret
+	secretKeyHMAC = "hmacSecret"
+
+	hmacAlgorithmSHA1   = "SHA1"
+	hmacAlgorithmSHA256 = "SHA256"
+	hmacAlgorithmSHA512 = "SHA512"
+
+	hmacEncodingHex    = "hex"
+	hmacEncodingBase64 = "base64"
+
+	// Placeholders of the signing string layout
+	placeholderTimestamp = "{timestamp}"
+	placeholderNonce     = "{nonce}"
+	placeholderMethod    = "{method}"
+	placeholderPath      = "{path}"
+	placeholderBody      = "{body}"
+
+	defaultSigningString = placeholderTimestamp + "." + placeholderBody
+	// defaultMaxBodySize bounds the signed body read into memory when MaxBodySize isn't set
+	defaultMaxBodySize = 4 << 20
+	// maxNonces bounds the memory of the nonce cache
+	maxNonces = 100000
+)
+
+// errRequestTooLarge rejects signed requests whose body exceeds the MaxBodySize
+var errRequestTooLarge = errors.New("request body too large")
+
+// HMACConfig holds the settings for verifying HMAC signed requests
+type HMACConfig struct {
+	// Algorithm is the hash function of the HMAC: SHA1, SHA256 or SHA512
+	Algorithm string
+	// SignatureHeader is the request header holding the signature
+	SignatureHeader string
+	// SignaturePrefix is stripped from the signature before decoding, e.g. "sha256="
+	SignaturePrefix string
+	// Encoding of the signature, hex or base64
+	Encoding string
+	// TimestampHeader is the request header holding the time of signing, as epoch
+	// timestamp or RFC3339
+	TimestampHeader string
+	// NonceHeader is the request header holding a value unique to each request.
+	// Without it the signature itself is used to detect duplicates
+	NonceHeader string
+	// SigningString is the layout of the signed data with the placeholders {timestamp},
+	// {nonce}, {method}, {path} and {body}
+	SigningString string
+	// ReplayWindow is how far the signed timestamp may differ from the service clock
+	ReplayWindow string
+	// MaxBodySize is the maximum size in bytes of signed request bodies, which are
+	// read into memory before the signature is verified. Zero means 4 MiB
+	MaxBodySize int64
+}
+
+// Validate ensures the HMAC configuration has proper values
+func (c HMACConfig) Validate() error {
+	if _, err := c.hashFunc(); err != nil {
+		return err
+	}
+	switch c.Encoding {
+	case "", hmacEncodingHex, hmacEncodingBase64:
+	default:
+		return fmt.Errorf("invalid Encoding '%s', must be %s or %s", c.Encoding, hmacEncodingHex, hmacEncodingBase64)
+	}
+	if c.SignatureHeader == "" {
+		return errors.New("SignatureHeader must not be empty")
+	}
+	if c.TimestampHeader == "" {
+		return errors.New("TimestampHeader must not be empty")
+	}
+	if window, err := parseDuration(c.ReplayWindow); err != nil || window == 0 {
+		return fmt.Errorf("invalid ReplayWindow '%s', must be a positive duration", c.ReplayWindow)
+	}
+	if c.SigningString != "" && !strings.Contains(c.SigningString, placeholderTimestamp) {
+		return fmt.Errorf("SigningString must contain %s", placeholderTimestamp)
+	}
+	if c.MaxBodySize < 0 {
+		return fmt.Errorf("invalid MaxBodySize %d, must not be negative", c.MaxBodySize)
+	}
+
+	return nil
+}
+
+func (c HMACConfig) hashFunc() (func() hash.Hash, error) {
+	switch c.Algorithm {
+	case hmacAlgorithmSHA1:
+		return sha1.New, nil
+	case "", hmacAlgorithmSHA256:
+		return sha256.New, nil
+	case hmacAlgorithmSHA512:
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("invalid Algorithm '%s', must be %s, %s or %s", c.Algorithm, hmacAlgorithmSHA1, hmacAlgorithmSHA256, hmacAlgorithmSHA512)
+	}
+}
+
+// nonceCache remembers the nonces seen within the replay window
+type nonceCache struct {
+	nonces map[string]time.Time
+	mutex  sync.Mutex
+}
+
+func newNonceCache() *nonceCache {
+	return &nonceCache{nonces: map[string]time.Time{}}
+}
+
+// add records the nonce until it expires, returning false if the nonce was already seen
+func (c *nonceCache) add(nonce string, expiry time.Time, now time.Time) (bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if expires, ok := c.nonces[nonce]; ok && expires.After(now) {
+		return false, nil
+	}
+
+	if len(c.nonces) >= maxNonces {
+		for key, expires := range c.nonces {
+			if !expires.After(now) {
+				delete(c.nonces, key)
+			}
+		}
+		if len(c.nonces) >= maxNonces {
+			return false, errors.New("too many signed requests within the replay window")
+		}
+	}
+
+	c.nonces[nonce] = expiry
+
+	return true, nil
+}
+
+// verifySignature checks the request is signed with the HMAC secret of the device,
+// that the signed timestamp is within the replay window and that the request isn't
+// a duplicate of an earlier one. The request body is restored for later processing.
+func (handler RestHandler) verifySignature(deviceName string, request *http.Request) error {
+	config := handler.config.HMAC
+
+	signature, err := decodeSignature(config, request.Header.Get(config.SignatureHeader))
+	if err != nil {
+		return err
+	}
+
+	timestampValue := request.Header.Get(config.TimestampHeader)
+	timestamp, err := parseOrigin(timestampValue)
+	if err != nil {
+		return fmt.Errorf("invalid signature timestamp: %s", err.Error())
+	}
+	now := time.Now()
+	window, _ := parseDuration(config.ReplayWindow)
+	signedAt := time.Unix(0, timestamp)
+	if signedAt.Before(now.Add(-window)) || signedAt.After(now.Add(window)) {
+		return errors.New("signature timestamp outside of the replay window")
+	}
+
+	nonce := request.Header.Get(config.NonceHeader)
+	if config.NonceHeader != "" && nonce == "" {
+		return errors.New("missing nonce")
+	}
+
+	secret, err := handler.deviceSecret(deviceName, secretKeyHMAC)
+	if err != nil {
+		return err
+	}
+
+	maxBodySize := config.MaxBodySize
+	if maxBodySize == 0 {
+		maxBodySize = defaultMaxBodySize
+	}
+	body, err := io.ReadAll(io.LimitReader(request.Body, maxBodySize+1))
+	_ = request.Body.Close()
+	if err != nil {
+		return fmt.Errorf("unable to read request body: %s", err.Error())
+	}
+	if int64(len(body)) > maxBodySize {
+		return errRequestTooLarge
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+
+	layout := config.SigningString
+	if layout == "" {
+		layout = defaultSigningString
+	}
+	signingString := strings.NewReplacer(
+		placeholderTimestamp, timestampValue,
+		placeholderNonce, nonce,
+		placeholderMethod, request.Method,
+		placeholderPath, request.URL.Path,
+		placeholderBody, string(body),
+	).Replace(layout)
+
+	hashFunc, _ := config.hashFunc()
+	mac := hmac.New(hashFunc, []byte(secret))
+	mac.Write([]byte(signingString))
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return errors.New("signature mismatch")
+	}
+
+	// Only requests with a valid signature are remembered, so that invalid requests
+	// can't fill up the cache
+	if nonce == "" {
+		nonce = hex.EncodeToString(signature)
+	}
+	fresh, err := handler.nonces.add(deviceName+"/"+nonce, signedAt.Add(window), now)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return errors.New("replayed request")
+	}
+
+	return nil
+}
+
+func decodeSignature(config HMACConfig, value string) ([]byte, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), config.SignaturePrefix)
+	if value == "" {
+		return nil, errors.New("missing signature")
+	}
+
+	var signature []byte
+	var err error
+	if config.Encoding == hmacEncodingBase64 {
+		signature, err = base64.StdEncoding.DecodeString(value)
+	} else {
+		signature, err = hex.DecodeString(value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature encoding: %s", err.Error())
+	}
+
+	return signature, nil
+}
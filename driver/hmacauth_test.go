@@ -0,0 +1,118 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces/mocks"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	secretProvider := &bootstrapMocks.SecretProvider{}
+	secretProvider.On("GetSecret", "sensorA-key").Return(map[string]string{secretKeyHMAC: "s3cr3t"}, nil)
+
+	service := &mocks.DeviceServiceSDK{}
+	service.On("LoggingClient").Return(logger.NewMockClient())
+	service.On("AsyncValuesChannel").Return(make(chan *sdkModels.AsyncValues))
+	service.On("SecretProvider").Return(secretProvider)
+	service.On("GetDeviceByName", "sensorA").Return(models.Device{Name: "sensorA", Protocols: map[string]models.ProtocolProperties{
+		"other": {IngestionSecretName: "sensorA-key"},
+	}}, nil)
+
+	config := CustomConfig{
+		IngestionAuth: IngestionAuthHMAC,
+		HMAC: HMACConfig{
+			Algorithm:       hmacAlgorithmSHA256,
+			SignatureHeader: "X-Signature",
+			SignaturePrefix: "sha256=",
+			TimestampHeader: "X-Timestamp",
+			SigningString:   "{timestamp}:{method}:{path}:{body}",
+			ReplayWindow:    "5m",
+			MaxBodySize:     64,
+		},
+	}
+	require.NoError(t, config.Validate())
+	hmacHandler := NewRestHandler(service, config)
+
+	sign := func(secret string, timestamp string, body string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp + ":POST:/api/v3/resource/sensorA:" + body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	newRequest := func(signature string, timestamp string, body string) *http.Request {
+		request := httptest.NewRequest(http.MethodPost, "/api/v3/resource/sensorA", strings.NewReader(body))
+		request.Header.Set("X-Signature", signature)
+		request.Header.Set("X-Timestamp", timestamp)
+		return request
+	}
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	body := `{"temperature": 21.5}`
+
+	request := newRequest(sign("s3cr3t", now, body), now, body)
+	require.NoError(t, hmacHandler.authorizeDevice("sensorA", request))
+	restored, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(restored), "body must be available after verification")
+
+	replayed := newRequest(sign("s3cr3t", now, body), now, body)
+	assert.ErrorIs(t, hmacHandler.authorizeDevice("sensorA", replayed), errUnauthorized, "replayed request")
+
+	otherBody := `{"temperature": 22.5}`
+	tampered := newRequest(sign("s3cr3t", now, body), now, otherBody)
+	assert.ErrorIs(t, hmacHandler.authorizeDevice("sensorA", tampered), errUnauthorized, "tampered body")
+
+	wrongSecret := newRequest(sign("other", now, otherBody), now, otherBody)
+	assert.ErrorIs(t, hmacHandler.authorizeDevice("sensorA", wrongSecret), errUnauthorized, "wrong secret")
+
+	old := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	expired := newRequest(sign("s3cr3t", old, otherBody), old, otherBody)
+	assert.ErrorIs(t, hmacHandler.authorizeDevice("sensorA", expired), errUnauthorized, "timestamp outside replay window")
+
+	unsigned := newRequest("", now, otherBody)
+	assert.ErrorIs(t, hmacHandler.authorizeDevice("sensorA", unsigned), errUnauthorized, "missing signature")
+
+	largeBody := `{"temperature": 22.5, "comment": "` + strings.Repeat("x", 64) + `"}`
+	tooLarge := newRequest(sign("s3cr3t", now, largeBody), now, largeBody)
+	assert.ErrorIs(t, hmacHandler.authorizeDevice("sensorA", tooLarge), errRequestTooLarge, "body over MaxBodySize")
+
+	fresh := newRequest(sign("s3cr3t", now, otherBody), now, otherBody)
+	assert.NoError(t, hmacHandler.authorizeDevice("sensorA", fresh))
+}
+
+func TestNonceCache(t *testing.T) {
+	cache := newNonceCache()
+	now := time.Now()
+
+	added, err := cache.add("a", now.Add(time.Minute), now)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = cache.add("a", now.Add(time.Minute), now)
+	require.NoError(t, err)
+	assert.False(t, added, "duplicate nonce within its expiry")
+
+	added, err = cache.add("a", now.Add(3*time.Minute), now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, added, "expired nonce may be used again")
+}
@@ -0,0 +1,231 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// HTTPClientConfig holds the settings of the HTTP clients sending commands to the
+// end devices. The same settings can be overridden per device by protocol properties.
+type HTTPClientConfig struct {
+	// ConnectTimeout limits establishing the connection, including the TLS handshake
+	ConnectTimeout string
+	// ReadTimeout limits waiting for the response headers once the request is sent
+	ReadTimeout string
+	// Timeout limits the whole request, including reading the response body
+	Timeout string
+	// KeepAlive is the interval of TCP keep-alive probes on the connections
+	KeepAlive string
+	// MaxIdleConns limits the idle connections kept open to a device for reuse
+	MaxIdleConns int
+	// IdleConnTimeout is how long an idle connection is kept open
+	IdleConnTimeout string
+}
+
+// Validate ensures the HTTP client configuration has proper values
+func (c HTTPClientConfig) Validate() error {
+	durations := map[string]string{
+		HTTPConnectTimeout:  c.ConnectTimeout,
+		HTTPReadTimeout:     c.ReadTimeout,
+		HTTPTimeout:         c.Timeout,
+		HTTPKeepAlive:       c.KeepAlive,
+		HTTPIdleConnTimeout: c.IdleConnTimeout,
+	}
+	for name, value := range durations {
+		if _, err := parseDuration(value); err != nil {
+			return fmt.Errorf("invalid %s: %s", name, err.Error())
+		}
+	}
+	if c.MaxIdleConns < 0 {
+		return fmt.Errorf("invalid %s: must not be negative", HTTPMaxIdleConns)
+	}
+
+	return nil
+}
+
+// merge returns the settings with the non-empty values of the overrides applied
+func (c HTTPClientConfig) merge(overrides HTTPClientConfig) httpClientSettings {
+	if overrides.ConnectTimeout != "" {
+		c.ConnectTimeout = overrides.ConnectTimeout
+	}
+	if overrides.ReadTimeout != "" {
+		c.ReadTimeout = overrides.ReadTimeout
+	}
+	if overrides.Timeout != "" {
+		c.Timeout = overrides.Timeout
+	}
+	if overrides.KeepAlive != "" {
+		c.KeepAlive = overrides.KeepAlive
+	}
+	if overrides.MaxIdleConns > 0 {
+		c.MaxIdleConns = overrides.MaxIdleConns
+	}
+	if overrides.IdleConnTimeout != "" {
+		c.IdleConnTimeout = overrides.IdleConnTimeout
+	}
+
+	// The values are validated when loading the configuration and the device
+	settings := httpClientSettings{maxIdleConns: c.MaxIdleConns}
+	settings.connectTimeout, _ = parseDuration(c.ConnectTimeout)
+	settings.readTimeout, _ = parseDuration(c.ReadTimeout)
+	settings.timeout, _ = parseDuration(c.Timeout)
+	settings.keepAlive, _ = parseDuration(c.KeepAlive)
+	settings.idleConnTimeout, _ = parseDuration(c.IdleConnTimeout)
+
+	return settings
+}
+
+// parseHTTPClientOverrides reads the HTTP client settings a device overrides with
+// its protocol properties
+func parseHTTPClientOverrides(protocolParams map[string]any) (HTTPClientConfig, error) {
+	var overrides HTTPClientConfig
+	durations := map[string]*string{
+		HTTPConnectTimeout:  &overrides.ConnectTimeout,
+		HTTPReadTimeout:     &overrides.ReadTimeout,
+		HTTPTimeout:         &overrides.Timeout,
+		HTTPKeepAlive:       &overrides.KeepAlive,
+		HTTPIdleConnTimeout: &overrides.IdleConnTimeout,
+	}
+	for name, value := range durations {
+		property, ok := protocolParams[name]
+		if !ok {
+			continue
+		}
+		text, err := cast.ToStringE(property)
+		if err != nil {
+			return overrides, fmt.Errorf("%s is not string type", name)
+		}
+		if _, err := parseDuration(text); err != nil {
+			return overrides, fmt.Errorf("invalid %s: %s", name, err.Error())
+		}
+		*value = text
+	}
+
+	if property, ok := protocolParams[HTTPMaxIdleConns]; ok {
+		maxIdleConns, err := cast.ToIntE(property)
+		if err != nil || maxIdleConns <= 0 {
+			return overrides, fmt.Errorf("invalid %s: must be a positive number", HTTPMaxIdleConns)
+		}
+		overrides.MaxIdleConns = maxIdleConns
+	}
+
+	return overrides, nil
+}
+
+// httpClientSettings are the resolved settings of a device's HTTP client
+type httpClientSettings struct {
+	connectTimeout  time.Duration
+	readTimeout     time.Duration
+	timeout         time.Duration
+	keepAlive       time.Duration
+	maxIdleConns    int
+	idleConnTimeout time.Duration
+	// TLS settings of the device, the fingerprint identifies its TLS material
+	tlsServerName         string
+	tlsInsecureSkipVerify bool
+	tlsFingerprint        string
+}
+
+type pooledClient struct {
+	client   *http.Client
+	settings httpClientSettings
+}
+
+// clientPool holds one HTTP client per device so that the connections to a device
+// are reused across commands
+type clientPool struct {
+	clients map[string]*pooledClient
+	mutex   sync.Mutex
+}
+
+func newClientPool() *clientPool {
+	return &clientPool{clients: map[string]*pooledClient{}}
+}
+
+// client returns the HTTP client of the device, replacing it when the device's
+// settings or TLS material changed
+func (p *clientPool) client(deviceName string, settings httpClientSettings, material tlsMaterial) (*http.Client, error) {
+	settings.tlsFingerprint = material.fingerprint()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	pooled, ok := p.clients[deviceName]
+	if ok && pooled.settings == settings {
+		return pooled.client, nil
+	}
+
+	client, err := newHTTPClient(settings, material)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		pooled.client.CloseIdleConnections()
+	}
+
+	p.clients[deviceName] = &pooledClient{client: client, settings: settings}
+
+	return client, nil
+}
+
+// remove closes the idle connections of the device and drops its client
+func (p *clientPool) remove(deviceName string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if pooled, ok := p.clients[deviceName]; ok {
+		pooled.client.CloseIdleConnections()
+		delete(p.clients, deviceName)
+	}
+}
+
+// close closes the idle connections of all devices
+func (p *clientPool) close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for deviceName, pooled := range p.clients {
+		pooled.client.CloseIdleConnections()
+		delete(p.clients, deviceName)
+	}
+}
+
+func newHTTPClient(settings httpClientSettings, material tlsMaterial) (*http.Client, error) {
+	tlsConfig, err := newTLSConfig(settings, material)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   settings.connectTimeout,
+		KeepAlive: settings.keepAlive,
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   settings.connectTimeout,
+		ResponseHeaderTimeout: settings.readTimeout,
+		MaxIdleConns:          settings.maxIdleConns,
+		MaxIdleConnsPerHost:   settings.maxIdleConns,
+		IdleConnTimeout:       settings.idleConnTimeout,
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   settings.timeout,
+	}, nil
+}
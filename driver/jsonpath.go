@@ -0,0 +1,243 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+)
+
+// jsonPathSegment selects a member of an object or an element of an array
+type jsonPathSegment struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+func (s jsonPathSegment) String() string {
+	if s.isIndex {
+		return fmt.Sprintf("[%d]", s.index)
+	}
+	return fmt.Sprintf("['%s']", s.name)
+}
+
+// parseJSONPath parses a JSONPath selecting a single value, e.g. $.data.items[0]['unit'],
+// or a JSON Pointer (RFC 6901), e.g. /data/items/0/unit. Wildcards, filters and
+// recursive descent select several values and aren't supported.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	if path == "" || strings.HasPrefix(path, "/") {
+		return parseJSONPointer(path), nil
+	}
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid %s '%s', must be a JSONPath starting with $ or a JSON Pointer starting with /", JSONPath, path)
+	}
+
+	var segments []jsonPathSegment
+	rest := path[1:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			return nil, fmt.Errorf("invalid %s '%s', recursive descent is not supported", JSONPath, path)
+		case rest[0] == '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			if name == "" || name == "*" {
+				return nil, fmt.Errorf("invalid %s '%s', member name expected after '.'", JSONPath, path)
+			}
+			segments = append(segments, jsonPathSegment{name: name})
+			rest = rest[end:]
+		case rest[0] == '[':
+			if len(rest) > 1 && (rest[1] == '\'' || rest[1] == '"') {
+				end := strings.IndexByte(rest[2:], rest[1])
+				if end < 0 || !strings.HasPrefix(rest[2+end+1:], "]") {
+					return nil, fmt.Errorf("invalid %s '%s', unterminated member name", JSONPath, path)
+				}
+				segments = append(segments, jsonPathSegment{name: rest[2 : 2+end]})
+				rest = rest[2+end+2:]
+				continue
+			}
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid %s '%s', missing ']'", JSONPath, path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s '%s', '%s' is not an array index", JSONPath, path, rest[1:end])
+			}
+			segments = append(segments, jsonPathSegment{index: index, isIndex: true})
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid %s '%s', unexpected '%c'", JSONPath, path, rest[0])
+		}
+	}
+
+	return segments, nil
+}
+
+// parseJSONPointer parses the reference tokens of a JSON Pointer, they select members
+// of objects or elements of arrays depending on the value they're applied to
+func parseJSONPointer(pointer string) []jsonPathSegment {
+	if pointer == "" {
+		return nil
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	segments := make([]jsonPathSegment, len(tokens))
+	for i, token := range tokens {
+		segments[i] = jsonPathSegment{name: strings.NewReplacer("~1", "/", "~0", "~").Replace(token)}
+	}
+
+	return segments
+}
+
+// selectJSONValue returns the value of the JSON document the path selects
+func selectJSONValue(body []byte, path string) (any, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var node any
+	if err := decoder.Decode(&node); err != nil {
+		return nil, fmt.Errorf("unable to parse response as JSON: %s", err.Error())
+	}
+
+	selected := "$"
+	for _, segment := range segments {
+		switch value := node.(type) {
+		case map[string]any:
+			if segment.isIndex {
+				return nil, fmt.Errorf("'%s' of response is an object, not an array", selected)
+			}
+			var ok bool
+			if node, ok = value[segment.name]; !ok {
+				return nil, fmt.Errorf("'%s' not found in response, '%s' has no member '%s'", path, selected, segment.name)
+			}
+		case []any:
+			index := segment.index
+			if !segment.isIndex {
+				if index, err = strconv.Atoi(segment.name); err != nil || index < 0 {
+					return nil, fmt.Errorf("'%s' of response is an array, not an object", selected)
+				}
+			}
+			if index < 0 {
+				index += len(value)
+			}
+			if index < 0 || index >= len(value) {
+				return nil, fmt.Errorf("'%s' not found in response, '%s' has %d elements", path, selected, len(value))
+			}
+			node = value[index]
+		default:
+			return nil, fmt.Errorf("'%s' not found in response, '%s' is a %s", path, selected, jsonKind(node))
+		}
+		selected += segment.String()
+	}
+
+	return node, nil
+}
+
+// selectJSONText returns the text of the string or number the path selects. Besides
+// JSONPaths and JSON Pointers it accepts dot separated member names, e.g. meta.ts.
+func selectJSONText(body []byte, path string) (string, error) {
+	if path != "" && !strings.HasPrefix(path, "$") && !strings.HasPrefix(path, "/") {
+		path = "$." + path
+	}
+	value, err := selectJSONValue(body, path)
+	if err != nil {
+		return "", err
+	}
+
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	default:
+		return "", fmt.Errorf("'%s' of response is a %s, expected a string or a number", path, jsonKind(value))
+	}
+}
+
+// jsonKind names the JSON type of a decoded value
+func jsonKind(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+// jsonPathReading selects the value of a JSON response and returns it as reading of
+// the value type along with the content type to validate it with. The selected value
+// must have the JSON type matching the value type.
+func jsonPathReading(body []byte, path string, valueType string) (any, string, error) {
+	value, err := selectJSONValue(body, path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var expected string
+	switch valueType {
+	case common.ValueTypeObject:
+		expected = "object"
+	case common.ValueTypeBool:
+		expected = "boolean"
+	case common.ValueTypeString:
+		expected = "string"
+	case common.ValueTypeUint8, common.ValueTypeUint16, common.ValueTypeUint32, common.ValueTypeUint64,
+		common.ValueTypeInt8, common.ValueTypeInt16, common.ValueTypeInt32, common.ValueTypeInt64,
+		common.ValueTypeFloat32, common.ValueTypeFloat64:
+		expected = "number"
+	case common.ValueTypeBinary:
+		return nil, "", fmt.Errorf("%s is not supported for %s resources", JSONPath, valueType)
+	default:
+		if _, ok := arrayElementTypes[valueType]; !ok {
+			return nil, "", fmt.Errorf("unsupported value type: %v", valueType)
+		}
+		expected = "array"
+	}
+	if kind := jsonKind(value); kind != expected {
+		return nil, "", fmt.Errorf("'%s' of response is a %s, expected a %s for %s", path, kind, expected, valueType)
+	}
+
+	switch value := value.(type) {
+	case string:
+		return value, common.ContentTypeText, nil
+	case json.Number:
+		return value.String(), common.ContentTypeText, nil
+	case bool:
+		return strconv.FormatBool(value), common.ContentTypeText, nil
+	case map[string]any:
+		data, err := json.Marshal(value)
+		return data, common.ContentTypeJSON, err
+	case []any:
+		data, err := json.Marshal(value)
+		return string(data), common.ContentTypeJSON, err
+	}
+
+	return nil, "", errors.New("unexpected JSON value")
+}
@@ -0,0 +1,143 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonPathResponse = `{"temperature":21.5,"unit":"C","on":true,"levels":[1,2],"data":{"items":[{"id":1},{"id":2}],"a/b":{"x~y":"z"},"none":null}}`
+
+func TestSelectJSONValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		expectedValue any
+		expectedError bool
+	}{
+		{"member", "$.temperature", json.Number("21.5"), false},
+		{"nested", "$.data.items[1].id", json.Number("2"), false},
+		{"negative index", "$.data.items[-1].id", json.Number("2"), false},
+		{"bracket names", `$['data']["a/b"]['x~y']`, "z", false},
+		{"pointer", "/data/items/0/id", json.Number("1"), false},
+		{"pointer escapes", "/data/a~1b/x~0y", "z", false},
+		{"pointer whole document", "", map[string]any{}, false},
+		{"null", "$.data.none", nil, false},
+		{"missing member", "$.data.missing", nil, true},
+		{"index out of range", "$.data.items[2]", nil, true},
+		{"index of object", "$.data[0]", nil, true},
+		{"member of array", "/data/items/first", nil, true},
+		{"member of scalar", "$.unit.value", nil, true},
+		{"recursive descent", "$..id", nil, true},
+		{"wildcard", "$.data.*", nil, true},
+		{"unterminated name", "$['data", nil, true},
+		{"invalid index", "$.data.items[x]", nil, true},
+		{"no root", "temperature", nil, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			value, err := selectJSONValue([]byte(jsonPathResponse), testCase.path)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if _, ok := testCase.expectedValue.(map[string]any); ok {
+				assert.IsType(t, testCase.expectedValue, value)
+				return
+			}
+			assert.Equal(t, testCase.expectedValue, value)
+		})
+	}
+
+	_, err := selectJSONValue([]byte("21.5 C"), "$.temperature")
+	assert.Error(t, err)
+}
+
+func TestSelectJSONText(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		expectedText  string
+		expectedError bool
+	}{
+		{"JSONPath", "$.unit", "C", false},
+		{"JSON Pointer", "/data/items/1/id", "2", false},
+		{"dot separated", "data.a/b.x~y", "z", false},
+		{"number", "temperature", "21.5", false},
+		{"boolean", "on", "", true},
+		{"object", "data", "", true},
+		{"missing", "data.missing", "", true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			text, err := selectJSONText([]byte(jsonPathResponse), testCase.path)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedText, text)
+		})
+	}
+}
+
+func TestHandleReadCommandsJSONPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(common.ContentType, common.ContentTypeJSON)
+		_, _ = w.Write([]byte(jsonPathResponse))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		valueType     string
+		path          string
+		expectedValue any
+		expectedError bool
+	}{
+		{"float", common.ValueTypeFloat64, "$.temperature", 21.5, false},
+		{"string", common.ValueTypeString, "/unit", "C", false},
+		{"bool", common.ValueTypeBool, "$.on", true, false},
+		{"int", common.ValueTypeInt32, "$.data.items[0].id", int32(1), false},
+		{"object", common.ValueTypeObject, "$.data.a/b", map[string]any{"x~y": "z"}, false},
+		{"object bracket", common.ValueTypeObject, "$.data['a/b']", map[string]any{"x~y": "z"}, false},
+		{"array", common.ValueTypeInt64Array, "$.levels", []int64{1, 2}, false},
+		{"array of objects", common.ValueTypeStringArray, "$.data.items", nil, true},
+		{"missing", common.ValueTypeFloat64, "$.humidity", nil, true},
+		{"string for number", common.ValueTypeFloat64, "$.unit", nil, true},
+		{"number for string", common.ValueTypeString, "$.temperature", nil, true},
+		{"null", common.ValueTypeFloat64, "$.data.none", nil, true},
+		{"array for number", common.ValueTypeFloat64, "$.levels", nil, true},
+		{"binary", common.ValueTypeBinary, "$.unit", nil, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: testCase.valueType}}
+			driver, _ := newTestDriver(t, CustomConfig{}, "device", resource)
+			reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: testCase.valueType, Attributes: map[string]any{JSONPath: testCase.path}}}
+
+			responses, err := driver.HandleReadCommands("device", restProtocols(t, server, nil), reqs)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, responses, 1)
+			assert.Equal(t, testCase.expectedValue, responses[0].Value)
+		})
+	}
+}
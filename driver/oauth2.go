@@ -0,0 +1,313 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+)
+
+const (
+	// AuthMethodOAuth2 obtains access tokens with the OAuth2 client credentials grant
+	AuthMethodOAuth2 = "OAuth2"
+
+	// Keys of the device's auth secret holding the OAuth2 client credentials
+	secretKeyClientID     = "clientId"
+	secretKeyClientSecret = "clientSecret"
+
+	// How the client credentials are sent to the token endpoint
+	oauth2ClientAuthHeader = "Header"
+	oauth2ClientAuthBody   = "Body"
+
+	// maxTokenResponseSize bounds the token endpoint's response read into memory
+	maxTokenResponseSize = 1 << 20
+)
+
+// OAuth2Config holds the settings of the OAuth2 access tokens
+type OAuth2Config struct {
+	// RefreshBefore is how long before its expiry a token is refreshed in the
+	// background, at most half of the token's lifetime
+	RefreshBefore string
+}
+
+// Validate ensures the OAuth2 configuration has proper values
+func (c OAuth2Config) Validate() error {
+	if _, err := parseDuration(c.RefreshBefore); err != nil {
+		return fmt.Errorf("invalid RefreshBefore: %s", err.Error())
+	}
+
+	return nil
+}
+
+// oauth2Client identifies the OAuth2 client of a device, devices with the same client
+// share its tokens
+type oauth2Client struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       string
+	clientAuth   string
+}
+
+// key identifies the client in the token cache. It holds a hash of the secret, so
+// that a rotated secret doesn't reuse the token of the old one.
+func (c oauth2Client) key() string {
+	secret := sha256.Sum256([]byte(c.clientSecret))
+	return strings.Join([]string{c.tokenURL, c.clientID, hex.EncodeToString(secret[:]), c.scopes}, "|")
+}
+
+type oauth2Token struct {
+	accessToken string
+	issuedAt    time.Time
+	// expiry is zero when the token endpoint didn't tell the lifetime, the token is
+	// then used until it's rejected
+	expiry time.Time
+}
+
+// expired checks whether the token can't be used anymore
+func (t oauth2Token) expired(now time.Time) bool {
+	return t.accessToken == "" || (!t.expiry.IsZero() && !now.Before(t.expiry))
+}
+
+// stale checks whether the token is due for refresh
+func (t oauth2Token) stale(now time.Time, refreshBefore time.Duration) bool {
+	if t.expiry.IsZero() {
+		return false
+	}
+	refreshBefore = min(refreshBefore, t.expiry.Sub(t.issuedAt)/2)
+
+	return !now.Before(t.expiry.Add(-refreshBefore))
+}
+
+type tokenEntry struct {
+	token      oauth2Token
+	refreshing bool
+	// mutex is held while fetching a token, so that a client's token is only
+	// fetched once when several commands need it at the same time
+	mutex sync.Mutex
+}
+
+// tokenCache holds the access tokens of each OAuth2 client
+type tokenCache struct {
+	entries map[string]*tokenEntry
+	// clients holds one HTTP client per token URL, the token endpoint is another
+	// host than the devices so it's not reached with their TLS settings
+	clients *clientPool
+	mutex   sync.Mutex
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{entries: map[string]*tokenEntry{}, clients: newClientPool()}
+}
+
+func (c *tokenCache) entry(client oauth2Client) *tokenEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[client.key()]
+	if !ok {
+		entry = &tokenEntry{}
+		c.entries[client.key()] = entry
+	}
+
+	return entry
+}
+
+// parseOAuth2Settings reads the OAuth2 settings of the device from its protocol properties
+func parseOAuth2Settings(protocolParams map[string]any, params *RestProtocolParams) error {
+	tokenURL, ok := protocolParams[OAuth2TokenURL]
+	if !ok {
+		return fmt.Errorf("%s is required with %s %s", OAuth2TokenURL, AuthMethod, AuthMethodOAuth2)
+	}
+	params.tokenURL = fmt.Sprint(tokenURL)
+	if parsed, err := url.Parse(params.tokenURL); err != nil || !parsed.IsAbs() {
+		return fmt.Errorf("invalid %s '%s', must be an absolute URL", OAuth2TokenURL, params.tokenURL)
+	}
+
+	if scopes, ok := protocolParams[OAuth2Scopes]; ok {
+		params.oauth2Scopes = strings.Join(strings.Fields(strings.ReplaceAll(fmt.Sprint(scopes), ",", " ")), " ")
+	}
+
+	params.oauth2ClientAuth = oauth2ClientAuthHeader
+	if clientAuth, ok := protocolParams[OAuth2ClientAuth]; ok {
+		params.oauth2ClientAuth = fmt.Sprint(clientAuth)
+		if params.oauth2ClientAuth != oauth2ClientAuthHeader && params.oauth2ClientAuth != oauth2ClientAuthBody {
+			return fmt.Errorf("invalid %s '%s', must be %s or %s", OAuth2ClientAuth, params.oauth2ClientAuth, oauth2ClientAuthHeader, oauth2ClientAuthBody)
+		}
+	}
+
+	return nil
+}
+
+// oauth2Client returns the OAuth2 client of the device with the credentials of its auth secret
+func (driver *RestDriver) oauth2Client(protocolParams RestProtocolParams) (oauth2Client, error) {
+	secrets, err := driver.authSecret(protocolParams, secretKeyClientID, secretKeyClientSecret)
+	if err != nil {
+		return oauth2Client{}, err
+	}
+
+	return oauth2Client{
+		tokenURL:     protocolParams.tokenURL,
+		clientID:     secrets[secretKeyClientID],
+		clientSecret: secrets[secretKeyClientSecret],
+		scopes:       protocolParams.oauth2Scopes,
+		clientAuth:   protocolParams.oauth2ClientAuth,
+	}, nil
+}
+
+// oauth2Token returns a valid access token of the device's OAuth2 client. Tokens are
+// cached until they expire and refreshed in the background shortly before.
+func (driver *RestDriver) oauth2Token(protocolParams RestProtocolParams) (string, error) {
+	client, err := driver.oauth2Client(protocolParams)
+	if err != nil {
+		return "", err
+	}
+	httpClient, err := driver.tokenHTTPClient(protocolParams.tokenURL)
+	if err != nil {
+		return "", err
+	}
+
+	entry := driver.tokens.entry(client)
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	now := time.Now()
+	if !entry.token.expired(now) {
+		refreshBefore, _ := parseDuration(driver.config.AppCustom.OAuth2.RefreshBefore)
+		if entry.token.stale(now, refreshBefore) && !entry.refreshing {
+			entry.refreshing = true
+			go driver.refreshToken(httpClient, client, entry)
+		}
+		return entry.token.accessToken, nil
+	}
+
+	token, err := fetchToken(httpClient, client)
+	if err != nil {
+		return "", err
+	}
+	entry.token = token
+
+	return token.accessToken, nil
+}
+
+// tokenHTTPClient returns the HTTP client of the token endpoint. It has the timeouts
+// of the service's HTTP client configuration and verifies the endpoint with the
+// system roots, none of the devices' TLS settings apply.
+func (driver *RestDriver) tokenHTTPClient(tokenURL string) (*http.Client, error) {
+	settings := driver.config.AppCustom.HTTPClient.merge(HTTPClientConfig{})
+
+	return driver.tokens.clients.client(tokenURL, settings, tlsMaterial{})
+}
+
+// refreshToken replaces the cached token of the client with a fresh one, the cached
+// token is still used until then
+func (driver *RestDriver) refreshToken(httpClient *http.Client, client oauth2Client, entry *tokenEntry) {
+	token, err := fetchToken(httpClient, client)
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	entry.refreshing = false
+	if err != nil {
+		driver.logger.Warnf("Unable to refresh OAuth2 token of client '%s': %s", client.clientID, err.Error())
+		return
+	}
+	entry.token = token
+}
+
+// invalidateToken drops the cached token of the device's OAuth2 client, unless it
+// was already replaced by another one than the rejected token
+func (driver *RestDriver) invalidateToken(protocolParams RestProtocolParams, rejected string) {
+	client, err := driver.oauth2Client(protocolParams)
+	if err != nil {
+		return
+	}
+
+	entry := driver.tokens.entry(client)
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if entry.token.accessToken == rejected {
+		entry.token = oauth2Token{}
+	}
+}
+
+// fetchToken requests an access token with the client credentials grant (RFC 6749 4.4)
+func fetchToken(httpClient *http.Client, client oauth2Client) (oauth2Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if client.scopes != "" {
+		form.Set("scope", client.scopes)
+	}
+	if client.clientAuth == oauth2ClientAuthBody {
+		form.Set("client_id", client.clientID)
+		form.Set("client_secret", client.clientSecret)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, client.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("token request creation failed: %v", err)
+	}
+	request.Header.Set(common.ContentType, "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", common.ContentTypeJSON)
+	if client.clientAuth != oauth2ClientAuthBody {
+		request.SetBasicAuth(url.QueryEscape(client.clientID), url.QueryEscape(client.clientSecret))
+	}
+
+	issuedAt := time.Now()
+	resp, err := httpClient.Do(request)
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("token request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTokenResponseSize))
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("unable to read token response: %v", err)
+	}
+
+	var tokenResponse struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	jsonErr := json.Unmarshal(body, &tokenResponse)
+	if resp.StatusCode != http.StatusOK {
+		if tokenResponse.Error != "" {
+			return oauth2Token{}, fmt.Errorf("token request failed with status code %d: %s %s", resp.StatusCode, tokenResponse.Error, tokenResponse.ErrorDescription)
+		}
+		return oauth2Token{}, fmt.Errorf("token request failed with status code %d", resp.StatusCode)
+	}
+	if jsonErr != nil {
+		return oauth2Token{}, fmt.Errorf("invalid token response: %v", jsonErr)
+	}
+	if tokenResponse.AccessToken == "" {
+		return oauth2Token{}, errors.New("token response has no access_token")
+	}
+	if tokenResponse.TokenType != "" && !strings.EqualFold(tokenResponse.TokenType, AuthMethodBearer) {
+		return oauth2Token{}, fmt.Errorf("unsupported token type '%s'", tokenResponse.TokenType)
+	}
+
+	token := oauth2Token{accessToken: tokenResponse.AccessToken, issuedAt: issuedAt}
+	if tokenResponse.ExpiresIn > 0 {
+		token.expiry = issuedAt.Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,183 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// oauth2Server issues tokens for the client credentials and accepts them at /api,
+// unless they were revoked
+type oauth2Server struct {
+	*httptest.Server
+	issued  int
+	revoked map[string]bool
+	mutex   sync.Mutex
+}
+
+func newOAuth2Server(t *testing.T) *oauth2Server {
+	server := &oauth2Server{revoked: map[string]bool{}}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		if r.URL.Path == "/token" {
+			clientID, clientSecret, ok := r.BasicAuth()
+			if !ok || clientID != "client" || clientSecret != "s3cr3t" || r.FormValue("grant_type") != "client_credentials" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+				return
+			}
+			server.issued++
+			w.Header().Set(common.ContentType, common.ContentTypeJSON)
+			_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":3600}`, server.issued)
+			return
+		}
+
+		token := r.Header.Get("Authorization")
+		if token != fmt.Sprintf("Bearer token-%d", server.issued) || server.revoked[token] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("21.5"))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (s *oauth2Server) revoke(token string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.revoked["Bearer "+token] = true
+}
+
+func (s *oauth2Server) issuedTokens() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.issued
+}
+
+func TestOAuth2(t *testing.T) {
+	server := newOAuth2Server(t)
+	resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	driver, service := newTestDriver(t, CustomConfig{OAuth2: OAuth2Config{RefreshBefore: "60s"}}, "device1", resource)
+	service.On("DeviceResource", "device2", resource.Name).Return(resource, true)
+	secretProvider := &bootstrapMocks.SecretProvider{}
+	secretProvider.On("GetSecret", "client-auth").Return(map[string]string{secretKeyClientID: "client", secretKeyClientSecret: "s3cr3t"}, nil)
+	secretProvider.On("GetSecret", "wrong-auth").Return(map[string]string{secretKeyClientID: "client", secretKeyClientSecret: "wrong"}, nil)
+	service.On("SecretProvider").Return(secretProvider)
+	protocols := restProtocols(t, server.Server, map[string]any{AuthMethod: AuthMethodOAuth2, AuthSecretName: "client-auth", OAuth2TokenURL: server.URL + "/token"})
+	reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64}}
+
+	// Devices with the same client share the token
+	for _, deviceName := range []string{"device1", "device2", "device1"} {
+		responses, err := driver.HandleReadCommands(deviceName, protocols, reqs)
+		require.NoError(t, err)
+		assert.Equal(t, 21.5, responses[0].Value)
+	}
+	assert.Equal(t, 1, server.issuedTokens())
+
+	// A revoked token is replaced and the request sent once more
+	server.revoke("token-1")
+	_, err := driver.HandleReadCommands("device1", protocols, reqs)
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.issuedTokens())
+
+	// The token endpoint rejecting the client fails the command, the token of the
+	// client's right secret isn't used
+	protocols[RESTProtocol][AuthSecretName] = "wrong-auth"
+	_, err = driver.HandleReadCommands("device1", protocols, reqs)
+	assert.ErrorContains(t, err, "invalid_client")
+}
+
+func TestOAuth2TokenClient(t *testing.T) {
+	server := newOAuth2Server(t)
+	driver, service := newTestDriver(t, CustomConfig{HTTPClient: HTTPClientConfig{Timeout: "3s"}}, "device")
+	secretProvider := &bootstrapMocks.SecretProvider{}
+	secretProvider.On("GetSecret", "client-auth").Return(map[string]string{secretKeyClientID: "client", secretKeyClientSecret: "s3cr3t"}, nil)
+	service.On("SecretProvider").Return(secretProvider)
+
+	// The device's TLS secret and server name must not be used for the token endpoint,
+	// the mocked secret provider fails the test when the TLS secret is read
+	protocols := restProtocols(t, server.Server, map[string]any{
+		RESTScheme:     schemeHTTPS,
+		TLSSecretName:  "device-tls",
+		TLSServerName:  "device.example.com",
+		AuthMethod:     AuthMethodOAuth2,
+		AuthSecretName: "client-auth",
+		OAuth2TokenURL: server.URL + "/token",
+	})
+	params, err := getDeviceParameters(protocols)
+	require.NoError(t, err)
+	token, err := driver.oauth2Token(params)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	client, err := driver.tokenHTTPClient(params.tokenURL)
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Second, client.Timeout)
+	tlsConfig := client.Transport.(*http.Transport).TLSClientConfig
+	assert.Empty(t, tlsConfig.ServerName)
+	assert.Nil(t, tlsConfig.RootCAs, "the system roots must be used")
+	assert.Empty(t, tlsConfig.Certificates)
+}
+
+func TestOAuth2TokenRefresh(t *testing.T) {
+	now := time.Now()
+	token := oauth2Token{accessToken: "token", issuedAt: now, expiry: now.Add(time.Hour)}
+
+	assert.False(t, token.expired(now))
+	assert.False(t, token.stale(now, time.Minute))
+	assert.True(t, token.stale(now.Add(59*time.Minute), time.Minute))
+	assert.True(t, token.expired(now.Add(time.Hour)))
+
+	// Short lived tokens are refreshed after half of their lifetime
+	token.expiry = now.Add(time.Minute)
+	assert.False(t, token.stale(now.Add(29*time.Second), time.Hour))
+	assert.True(t, token.stale(now.Add(30*time.Second), time.Hour))
+
+	// Tokens without known lifetime are used until they are rejected
+	token.expiry = time.Time{}
+	assert.False(t, token.expired(now.Add(24*time.Hour)))
+	assert.False(t, token.stale(now.Add(24*time.Hour), time.Minute))
+}
+
+func TestGetDeviceParametersOAuth2(t *testing.T) {
+	protocols := map[string]models.ProtocolProperties{
+		RESTProtocol: {RESTHost: "localhost", RESTPort: "5000", RESTPath: "", AuthMethod: "OAuth2", AuthSecretName: "client-auth"},
+	}
+	_, err := getDeviceParameters(protocols)
+	assert.Error(t, err, "the token URL is required")
+
+	protocols[RESTProtocol][OAuth2TokenURL] = "/token"
+	_, err = getDeviceParameters(protocols)
+	assert.Error(t, err, "the token URL must be absolute")
+
+	protocols[RESTProtocol][OAuth2TokenURL] = "https://auth.example.com/token"
+	protocols[RESTProtocol][OAuth2Scopes] = "read, write"
+	params, err := getDeviceParameters(protocols)
+	require.NoError(t, err)
+	assert.Equal(t, "read write", params.oauth2Scopes)
+	assert.Equal(t, oauth2ClientAuthHeader, params.oauth2ClientAuth)
+
+	protocols[RESTProtocol][OAuth2ClientAuth] = "Cookie"
+	_, err = getDeviceParameters(protocols)
+	assert.Error(t, err)
+}
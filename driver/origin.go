@@ -0,0 +1,150 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// originRequestHeader and originQueryParam let clients supply the origin of the
+	// readings they post
+	originRequestHeader = "X-Origin"
+	originQueryParam    = "origin"
+
+	// Epoch timestamps below these magnitudes are taken as seconds, milliseconds and
+	// microseconds respectively, anything above as nanoseconds
+	maxEpochSeconds      = 1e11
+	maxEpochMilliseconds = 1e14
+	maxEpochMicroseconds = 1e17
+)
+
+// parseOrigin parses a reading origin given either as RFC3339 timestamp or as epoch
+// seconds, milliseconds, microseconds or nanoseconds and returns it as nanoseconds
+func parseOrigin(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, errors.New("empty origin")
+	}
+
+	epoch, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		timestamp, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return 0, fmt.Errorf("origin '%s' is neither an RFC3339 timestamp nor an epoch timestamp", value)
+		}
+		return timestamp.UnixNano(), nil
+	}
+
+	if epoch <= 0 {
+		return 0, fmt.Errorf("origin '%s' must be positive", value)
+	}
+
+	switch {
+	case epoch < maxEpochSeconds:
+		return epoch * int64(time.Second), nil
+	case epoch < maxEpochMilliseconds:
+		return epoch * int64(time.Millisecond), nil
+	case epoch < maxEpochMicroseconds:
+		return epoch * int64(time.Microsecond), nil
+	default:
+		return epoch, nil
+	}
+}
+
+// parseJSONOrigin parses a reading origin given as JSON number or JSON string
+func parseJSONOrigin(raw json.RawMessage) (int64, error) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		value = string(raw)
+	}
+
+	return parseOrigin(value)
+}
+
+// checkOrigin rejects origins that are further in the future than the allowed
+// clock skew or older than the allowed maximum age
+func (c CustomConfig) checkOrigin(origin int64, now time.Time) error {
+	maxSkew, _ := parseDuration(c.MaxOriginSkew)
+	maxAge, _ := parseDuration(c.MaxOriginAge)
+
+	timestamp := time.Unix(0, origin)
+	if timestamp.After(now.Add(maxSkew)) {
+		return fmt.Errorf("origin %s is in the future", timestamp.UTC().Format(time.RFC3339Nano))
+	}
+	if maxAge > 0 && timestamp.Before(now.Add(-maxAge)) {
+		return fmt.Errorf("origin %s is older than %s", timestamp.UTC().Format(time.RFC3339Nano), maxAge)
+	}
+
+	return nil
+}
+
+// requestOrigin returns the origin supplied with the request, either by header or
+// query parameter, or zero if the request doesn't supply one
+func (c CustomConfig) requestOrigin(request *http.Request) (int64, error) {
+	value := request.Header.Get(originRequestHeader)
+	if value == "" {
+		value = request.URL.Query().Get(originQueryParam)
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	origin, err := parseOrigin(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := c.checkOrigin(origin, time.Now()); err != nil {
+		return 0, err
+	}
+
+	return origin, nil
+}
+
+// responseOrigin returns the origin of a reading read from the end device, taken
+// from the response header or JSON field selected by the resource attributes. Zero
+// is returned if the resource doesn't select an origin.
+func (c CustomConfig) responseOrigin(attributes map[string]interface{}, resp *http.Response, body []byte) (int64, error) {
+	var origin int64
+	var err error
+
+	if header, ok := attributes[OriginHeader]; ok {
+		headerName := fmt.Sprint(header)
+		value := resp.Header.Get(headerName)
+		if value == "" {
+			return 0, fmt.Errorf("origin header '%s' not found in response", headerName)
+		}
+		if timestamp, parseErr := http.ParseTime(value); parseErr == nil {
+			origin = timestamp.UnixNano()
+		} else if origin, err = parseOrigin(value); err != nil {
+			return 0, err
+		}
+	} else if field, ok := attributes[OriginField]; ok {
+		value, err := selectJSONText(body, fmt.Sprint(field))
+		if err != nil {
+			return 0, err
+		}
+		if origin, err = parseOrigin(value); err != nil {
+			return 0, err
+		}
+	} else {
+		return 0, nil
+	}
+
+	if err = c.checkOrigin(origin, time.Now()); err != nil {
+		return 0, err
+	}
+
+	return origin, nil
+}
@@ -0,0 +1,110 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOrigin(t *testing.T) {
+	expected := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC).UnixNano()
+
+	tests := []struct {
+		Name          string
+		Value         string
+		Expected      int64
+		ErrorExpected bool
+	}{
+		{"RFC3339", "2023-11-14T22:13:20Z", expected, false},
+		{"RFC3339 with offset", "2023-11-15T00:13:20+02:00", expected, false},
+		{"Epoch seconds", "1700000000", expected, false},
+		{"Epoch milliseconds", "1700000000000", expected, false},
+		{"Epoch microseconds", "1700000000000000", expected, false},
+		{"Epoch nanoseconds", "1700000000000000000", expected, false},
+		{"Empty", "", 0, true},
+		{"Negative", "-1700000000", 0, true},
+		{"Invalid", "yesterday", 0, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.Name, func(t *testing.T) {
+			origin, err := parseOrigin(testCase.Value)
+			if testCase.ErrorExpected {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.Expected, origin)
+		})
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	now := time.Now()
+	config := CustomConfig{MaxOriginSkew: "5s", MaxOriginAge: "1h"}
+
+	assert.NoError(t, config.checkOrigin(now.UnixNano(), now))
+	assert.NoError(t, config.checkOrigin(now.Add(4*time.Second).UnixNano(), now))
+	assert.Error(t, config.checkOrigin(now.Add(6*time.Second).UnixNano(), now))
+	assert.NoError(t, config.checkOrigin(now.Add(-59*time.Minute).UnixNano(), now))
+	assert.Error(t, config.checkOrigin(now.Add(-61*time.Minute).UnixNano(), now))
+
+	unlimited := CustomConfig{}
+	assert.NoError(t, unlimited.checkOrigin(now.Add(-24*365*time.Hour).UnixNano(), now))
+	assert.Error(t, unlimited.checkOrigin(now.Add(time.Second).UnixNano(), now))
+}
+
+func TestResponseOrigin(t *testing.T) {
+	timestamp := time.Now().Add(-time.Minute).Truncate(time.Second)
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Date", timestamp.UTC().Format(http.TimeFormat))
+	resp.Header.Set("X-Timestamp", timestamp.Format(time.RFC3339))
+	body := []byte(`{"value": 21.5, "epoch": ` + strconv.FormatInt(timestamp.UnixMilli(), 10) + `, "meta": {"ts": "` + timestamp.Format(time.RFC3339) + `"}}`)
+	config := CustomConfig{}
+
+	origin, err := config.responseOrigin(map[string]interface{}{}, resp, body)
+	require.NoError(t, err)
+	assert.Zero(t, origin)
+
+	origin, err = config.responseOrigin(map[string]interface{}{OriginHeader: "Date"}, resp, body)
+	require.NoError(t, err)
+	assert.Equal(t, timestamp.UnixNano(), origin)
+
+	origin, err = config.responseOrigin(map[string]interface{}{OriginHeader: "X-Timestamp"}, resp, body)
+	require.NoError(t, err)
+	assert.Equal(t, timestamp.UnixNano(), origin)
+
+	origin, err = config.responseOrigin(map[string]interface{}{OriginField: "meta.ts"}, resp, body)
+	require.NoError(t, err)
+	assert.Equal(t, timestamp.UnixNano(), origin)
+
+	origin, err = config.responseOrigin(map[string]interface{}{OriginField: "epoch"}, resp, body)
+	require.NoError(t, err)
+	assert.Equal(t, timestamp.UnixNano(), origin)
+
+	origin, err = config.responseOrigin(map[string]interface{}{OriginField: "$.meta['ts']"}, resp, body)
+	require.NoError(t, err)
+	assert.Equal(t, timestamp.UnixNano(), origin)
+
+	origin, err = config.responseOrigin(map[string]interface{}{OriginField: "/epoch"}, resp, body)
+	require.NoError(t, err)
+	assert.Equal(t, timestamp.UnixNano(), origin)
+
+	_, err = config.responseOrigin(map[string]interface{}{OriginField: "meta.missing"}, resp, body)
+	assert.Error(t, err)
+
+	_, err = config.responseOrigin(map[string]interface{}{OriginField: "meta"}, resp, body)
+	assert.Error(t, err)
+
+	_, err = config.responseOrigin(map[string]interface{}{OriginHeader: "X-Missing"}, resp, body)
+	assert.Error(t, err)
+}
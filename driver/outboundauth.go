@@ -0,0 +1,150 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// Authentication methods of the requests sent to the end devices
+	AuthMethodNone   = "None"
+	AuthMethodBasic  = "Basic"
+	AuthMethodBearer = "Bearer"
+	AuthMethodAPIKey = "APIKey"
+
+	// Keys of the device's auth secret holding the credentials, the API key and token
+	// share the keys of the ingestion secret
+	secretKeyUsername = "username"
+	secretKeyPassword = "password"
+)
+
+var authMethods = []string{AuthMethodNone, AuthMethodBasic, AuthMethodBearer, AuthMethodAPIKey, AuthMethodOAuth2, AuthMethodDigest, AuthMethodSession}
+
+// parseAuthMethod reads the authentication method of the device from its protocol
+// properties, None when not set
+func parseAuthMethod(protocolParams map[string]any) (string, error) {
+	method, ok := protocolParams[AuthMethod]
+	if !ok {
+		return AuthMethodNone, nil
+	}
+
+	for _, authMethod := range authMethods {
+		if strings.EqualFold(fmt.Sprint(method), authMethod) {
+			return authMethod, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid %s '%v', must be one of %s", AuthMethod, method, strings.Join(authMethods, ", "))
+}
+
+// authenticate adds the credentials of the device to the request. The credentials
+// are read from the secret store for every request so that rotated credentials
+// apply without restart.
+func (driver *RestDriver) authenticate(deviceName string, request *http.Request, protocolParams RestProtocolParams) error {
+	switch protocolParams.authMethod {
+	case AuthMethodBasic:
+		secrets, err := driver.authSecret(protocolParams, secretKeyUsername, secretKeyPassword)
+		if err != nil {
+			return err
+		}
+		request.SetBasicAuth(secrets[secretKeyUsername], secrets[secretKeyPassword])
+	case AuthMethodBearer:
+		secrets, err := driver.authSecret(protocolParams, secretKeyToken)
+		if err != nil {
+			return err
+		}
+		request.Header.Set(echo.HeaderAuthorization, bearerPrefix+secrets[secretKeyToken])
+	case AuthMethodAPIKey:
+		secrets, err := driver.authSecret(protocolParams, secretKeyAPIKey)
+		if err != nil {
+			return err
+		}
+		request.Header.Set(protocolParams.apiKeyHeader, secrets[secretKeyAPIKey])
+	case AuthMethodOAuth2:
+		token, err := driver.oauth2Token(protocolParams)
+		if err != nil {
+			return err
+		}
+		request.Header.Set(echo.HeaderAuthorization, bearerPrefix+token)
+	case AuthMethodDigest:
+		return driver.authenticateDigest(deviceName, request, protocolParams)
+	case AuthMethodSession:
+		return driver.authenticateSession(deviceName, request, protocolParams)
+	}
+
+	return nil
+}
+
+// sendAuthenticated sends the authenticated request to the device. A request rejected
+// with 401 Unauthorized is sent once more with fresh credentials, as OAuth2 tokens
+// may be revoked before they expire, Digest requires answering a new challenge and
+// sessions time out. Sessions are also renewed on 403 Forbidden, which devices
+// respond to a stale CSRF token.
+func (driver *RestDriver) sendAuthenticated(deviceName string, client *http.Client, request *http.Request, protocolParams RestProtocolParams) (*http.Response, error) {
+	resp, err := driver.sendRequest(deviceName, client, request, protocolParams)
+	if err != nil {
+		return resp, err
+	}
+	rejected := resp.StatusCode == http.StatusUnauthorized ||
+		(resp.StatusCode == http.StatusForbidden && protocolParams.authMethod == AuthMethodSession)
+	if !rejected {
+		return resp, nil
+	}
+
+	switch protocolParams.authMethod {
+	case AuthMethodSession:
+		driver.logger.Debugf("Session of device '%s' was rejected, logging in again", deviceName)
+		driver.invalidateSession(deviceName, request, protocolParams)
+	case AuthMethodOAuth2:
+		driver.invalidateToken(protocolParams, strings.TrimPrefix(request.Header.Get(echo.HeaderAuthorization), bearerPrefix))
+	case AuthMethodDigest:
+		challenge, err := parseDigestChallenge(resp.Header)
+		if err != nil {
+			driver.logger.Warnf("Unable to authenticate with device '%s': %s", deviceName, err.Error())
+			return resp, nil
+		}
+		driver.digests.set(deviceName, challenge)
+	default:
+		return resp, nil
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedResponseSize))
+	_ = resp.Body.Close()
+
+	if request.GetBody != nil {
+		if request.Body, err = request.GetBody(); err != nil {
+			return nil, fmt.Errorf("unable to reset request body: %v", err)
+		}
+	}
+	if err := driver.authenticate(deviceName, request, protocolParams); err != nil {
+		return nil, err
+	}
+
+	return driver.sendRequest(deviceName, client, request, protocolParams)
+}
+
+// authSecret reads the auth secret of the device, which must hold the given keys
+func (driver *RestDriver) authSecret(protocolParams RestProtocolParams, keys ...string) (map[string]string, error) {
+	secrets, err := driver.sdk.SecretProvider().GetSecret(protocolParams.authSecretName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get auth secret '%s': %s", protocolParams.authSecretName, err.Error())
+	}
+
+	for _, key := range keys {
+		if secrets[key] == "" {
+			return nil, fmt.Errorf("auth secret '%s' has no %s", protocolParams.authSecretName, key)
+		}
+	}
+
+	return secrets, nil
+}
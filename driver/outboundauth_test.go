@@ -0,0 +1,99 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboundAuth(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		_, _ = w.Write([]byte("21.5"))
+	}))
+	defer server.Close()
+
+	resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	driver, service := newTestDriver(t, CustomConfig{}, "device", resource)
+	secretProvider := &bootstrapMocks.SecretProvider{}
+	secretProvider.On("GetSecret", "device-auth").Return(map[string]string{
+		secretKeyUsername: "admin",
+		secretKeyPassword: "s3cr3t",
+		secretKeyToken:    "t0ken",
+		secretKeyAPIKey:   "k3y",
+	}, nil)
+	secretProvider.On("GetSecret", "empty-auth").Return(map[string]string{}, nil)
+	secretProvider.On("GetSecret", "missing-auth").Return(nil, errors.New("not found"))
+	service.On("SecretProvider").Return(secretProvider)
+	value, err := sdkModels.NewCommandValue(resource.Name, common.ValueTypeFloat64, 21.5)
+	require.NoError(t, err)
+	reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64}}
+
+	tests := []struct {
+		name           string
+		properties     map[string]any
+		expectedHeader string
+		expectedValue  string
+		expectedError  bool
+	}{
+		{"none", nil, "Authorization", "", false},
+		{"basic", map[string]any{AuthMethod: "Basic", AuthSecretName: "device-auth"}, "Authorization", "Basic YWRtaW46czNjcjN0", false},
+		{"bearer", map[string]any{AuthMethod: "bearer", AuthSecretName: "device-auth"}, "Authorization", "Bearer t0ken", false},
+		{"api key", map[string]any{AuthMethod: "APIKey", AuthSecretName: "device-auth"}, "X-API-Key", "k3y", false},
+		{"api key custom header", map[string]any{AuthMethod: "APIKey", AuthSecretName: "device-auth", APIKeyHeader: "X-Token"}, "X-Token", "k3y", false},
+		{"credentials missing in secret", map[string]any{AuthMethod: "Basic", AuthSecretName: "empty-auth"}, "", "", true},
+		{"secret not found", map[string]any{AuthMethod: "Bearer", AuthSecretName: "missing-auth"}, "", "", true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			protocols := restProtocols(t, server, testCase.properties)
+
+			header = nil
+			_, err := driver.HandleReadCommands("device", protocols, reqs)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, header, "request must not be sent without credentials")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedValue, header.Get(testCase.expectedHeader))
+
+			header = nil
+			err = driver.HandleWriteCommands("device", protocols, reqs, []*sdkModels.CommandValue{value})
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedValue, header.Get(testCase.expectedHeader))
+		})
+	}
+}
+
+func TestGetDeviceParametersAuth(t *testing.T) {
+	protocols := map[string]models.ProtocolProperties{
+		RESTProtocol: {RESTHost: "localhost", RESTPort: "5000", RESTPath: ""},
+	}
+	params, err := getDeviceParameters(protocols)
+	require.NoError(t, err)
+	assert.Equal(t, AuthMethodNone, params.authMethod)
+
+	protocols[RESTProtocol][AuthMethod] = "Kerberos"
+	_, err = getDeviceParameters(protocols)
+	assert.Error(t, err)
+
+	protocols[RESTProtocol][AuthMethod] = "Basic"
+	_, err = getDeviceParameters(protocols)
+	assert.Error(t, err, "the secret name is required")
+}
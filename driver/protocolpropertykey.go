@@ -20,4 +20,92 @@ const (
 	RESTPath     = "Path"
 	RESTProtocol = "REST"
 	URLRawQuery  = "urlRawQuery"
+
+	// Optional REST protocol properties overriding the HTTP client configuration
+	HTTPConnectTimeout  = "ConnectTimeout"
+	HTTPReadTimeout     = "ReadTimeout"
+	HTTPTimeout         = "Timeout"
+	HTTPKeepAlive       = "KeepAlive"
+	HTTPMaxIdleConns    = "MaxIdleConns"
+	HTTPIdleConnTimeout = "IdleConnTimeout"
+
+	// MaxConcurrentReads optionally overrides how many resources of the device are
+	// read at the same time
+	MaxConcurrentReads = "MaxConcurrentReads"
+
+	// Optional REST protocol properties for HTTPS
+	RESTScheme            = "Scheme"
+	TLSSecretName         = "TLSSecretName"
+	TLSServerName         = "TLSServerName"
+	TLSInsecureSkipVerify = "TLSInsecureSkipVerify"
+
+	// RESTHeaders is an optional JSON object of headers sent with every request to
+	// the device, values secret:<secretName>/<key> are read from the secret store
+	RESTHeaders = "Headers"
+
+	// Optional REST protocol properties overriding the retry configuration, the
+	// status codes and errors are comma separated lists
+	RetryMaxAttempts     = "MaxAttempts"
+	RetryBackoffBase     = "BackoffBase"
+	RetryBackoffCap      = "BackoffCap"
+	RetryJitter          = "Jitter"
+	RetryableStatusCodes = "RetryableStatusCodes"
+	RetryableErrors      = "RetryableErrors"
+	RetryPUT             = "RetryPUT"
+
+	// Optional REST protocol properties for authenticating with the end device, the
+	// auth secret holds the username and password, token or apiKey
+	AuthMethod     = "AuthMethod"
+	AuthSecretName = "AuthSecretName"
+	APIKeyHeader   = "APIKeyHeader"
+	// OAuth2 client credentials settings, the auth secret holds the clientId and clientSecret
+	OAuth2TokenURL   = "TokenURL"
+	OAuth2Scopes     = "Scopes"
+	OAuth2ClientAuth = "ClientAuth"
+	// Session login settings, the login body is a template of the auth secret's
+	// Username, Password and Secret values
+	LoginURL         = "LoginURL"
+	LoginBody        = "LoginBody"
+	LoginContentType = "LoginContentType"
+	SessionCookie    = "SessionCookie"
+	CSRFHeader       = "CSRFHeader"
+	CSRFField        = "CSRFField"
+	SessionLifetime  = "SessionLifetime"
+
+	// IngestionSecretName names the secret holding the credentials a device posts
+	// readings with, it may be defined in any of the device's protocols
+	IngestionSecretName = "IngestionSecretName"
+
+	// Device resource attributes
+	OriginHeader    = "originHeader"
+	OriginField     = "originField"
+	ReadMethod      = "readMethod"
+	WriteMethod     = "writeMethod"
+	ReadBody        = "readBody"
+	ReadContentType = "readContentType"
+	// URLPath is a path template with {name} placeholders for the resource name,
+	// request attributes and protocol properties. Paths starting with / replace the
+	// device's path, others are below it
+	URLPath = "urlPath"
+	// RequestHeaders are headers of the resource's requests, replacing the device's
+	// headers of the same name
+	RequestHeaders = "headers"
+	// JSONPath selects the value of a JSON response, either as JSONPath, e.g.
+	// $.data.temperature, or as JSON Pointer, e.g. /data/temperature
+	JSONPath = "jsonPath"
+	// Base64Decode selects decoding Binary values written as string from base64
+	Base64Decode = "base64Decode"
+	// WriteBody is a template of the write request body wrapping the value, either a
+	// Go template string or a JSON object with {value} placeholders
+	WriteBody        = "writeBody"
+	WriteContentType = "writeContentType"
+	// RequestGroup names the request the resource is sent with, the resources of a
+	// command in the same group are read with a single request and select their value
+	// of the shared response with jsonPath, or written with a single JSON object. The
+	// request is created from the attributes of the group's first resource, without
+	// urlPath it's sent to the device's path followed by the group name
+	RequestGroup = "requestGroup"
+	// WriteField names the field of the resource's value in the JSON object written
+	// for its request group, the resource name when not set
+	WriteField = "writeField"
 )
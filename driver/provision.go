@@ -0,0 +1,226 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+)
+
+const (
+	// profileRequestHeader and profileQueryParam let clients select the profile of
+	// an auto provisioned device
+	profileRequestHeader = "X-Device-Profile"
+	profileQueryParam    = "profile"
+
+	autoProvisionProtocol     = "other"
+	autoProvisionPollInterval = 100 * time.Millisecond
+)
+
+var errProvisioningNotAllowed = errors.New("device auto provisioning not allowed")
+
+// AutoProvisionConfig holds the settings for creating unknown devices on their first POST
+type AutoProvisionConfig struct {
+	// Enabled turns auto provisioning on
+	Enabled bool
+	// AllowedNames are the regular expressions a device name must match to be provisioned
+	AllowedNames []string
+	// ProfileRules select the profile by device name when the request doesn't
+	// select one, the first matching rule wins
+	ProfileRules []ProfileRule
+	// Timeout is how long to wait for a provisioned device to become available
+	Timeout string
+}
+
+// ProfileRule maps device names matching the regular expression to a profile
+type ProfileRule struct {
+	NamePattern string
+	ProfileName string
+}
+
+// Validate ensures the auto provisioning configuration has proper values
+func (c AutoProvisionConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.AllowedNames) == 0 {
+		return errors.New("AllowedNames must not be empty when auto provisioning is enabled")
+	}
+	for _, pattern := range c.AllowedNames {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid AllowedNames pattern '%s': %s", pattern, err.Error())
+		}
+	}
+	for _, rule := range c.ProfileRules {
+		if _, err := regexp.Compile(rule.NamePattern); err != nil {
+			return fmt.Errorf("invalid ProfileRules pattern '%s': %s", rule.NamePattern, err.Error())
+		}
+		if rule.ProfileName == "" {
+			return fmt.Errorf("ProfileRules pattern '%s' has no ProfileName", rule.NamePattern)
+		}
+	}
+	timeout, err := parseDuration(c.Timeout)
+	if err != nil {
+		return fmt.Errorf("invalid Timeout: %s", err.Error())
+	}
+	if timeout <= 0 {
+		return errors.New("invalid Timeout: must be positive when auto provisioning is enabled")
+	}
+
+	return nil
+}
+
+type profileRule struct {
+	pattern     *regexp.Regexp
+	profileName string
+}
+
+// deviceProvisioner creates unknown devices through the SDK
+type deviceProvisioner struct {
+	service      interfaces.DeviceServiceSDK
+	allowedNames []*regexp.Regexp
+	rules        []profileRule
+	timeout      time.Duration
+	// pending are the devices being provisioned, concurrent first POSTs of a device
+	// wait for its provisioning instead of adding it again
+	pending map[string]*provisioning
+	mutex   sync.Mutex
+}
+
+// provisioning is the provisioning of a device in progress, done is closed once err is set
+type provisioning struct {
+	done chan struct{}
+	err  error
+}
+
+// newDeviceProvisioner returns the provisioner for the configuration or nil if auto
+// provisioning is disabled. The configuration is expected to be validated.
+func newDeviceProvisioner(sdk interfaces.DeviceServiceSDK, config AutoProvisionConfig) *deviceProvisioner {
+	if !config.Enabled {
+		return nil
+	}
+
+	provisioner := &deviceProvisioner{service: sdk, pending: map[string]*provisioning{}}
+	for _, pattern := range config.AllowedNames {
+		if expression, err := regexp.Compile(pattern); err == nil {
+			provisioner.allowedNames = append(provisioner.allowedNames, expression)
+		}
+	}
+	for _, rule := range config.ProfileRules {
+		if expression, err := regexp.Compile(rule.NamePattern); err == nil {
+			provisioner.rules = append(provisioner.rules, profileRule{pattern: expression, profileName: rule.ProfileName})
+		}
+	}
+	provisioner.timeout, _ = parseDuration(config.Timeout)
+
+	return provisioner
+}
+
+// provision creates the device with the profile selected by the request or the
+// profile rules, unless the device name isn't allowed, and waits until the device
+// is available to the service
+func (p *deviceProvisioner) provision(deviceName string, request *http.Request) error {
+	if !p.allowed(deviceName) {
+		return fmt.Errorf("%w: device name '%s' is not allowed", errProvisioningNotAllowed, deviceName)
+	}
+
+	profileName := p.profileName(deviceName, request)
+	if profileName == "" {
+		return fmt.Errorf("%w: no profile selected for device '%s'", errProvisioningNotAllowed, deviceName)
+	}
+
+	// Concurrent first POSTs for the same device must only create it once, while
+	// other devices are provisioned independently
+	p.mutex.Lock()
+	if pending, ok := p.pending[deviceName]; ok {
+		p.mutex.Unlock()
+		<-pending.done
+		return pending.err
+	}
+	pending := &provisioning{done: make(chan struct{})}
+	p.pending[deviceName] = pending
+	p.mutex.Unlock()
+
+	pending.err = p.addDevice(deviceName, profileName)
+
+	p.mutex.Lock()
+	delete(p.pending, deviceName)
+	p.mutex.Unlock()
+	close(pending.done)
+
+	return pending.err
+}
+
+// addDevice adds the device unless it exists and waits until it's available
+func (p *deviceProvisioner) addDevice(deviceName string, profileName string) error {
+	if _, err := p.service.GetDeviceByName(deviceName); err == nil {
+		return nil
+	}
+
+	device := models.Device{
+		Name:           deviceName,
+		Description:    "Auto provisioned on first POST",
+		ProfileName:    profileName,
+		ServiceName:    p.service.Name(),
+		AdminState:     models.Unlocked,
+		OperatingState: models.Up,
+		Protocols: map[string]models.ProtocolProperties{
+			autoProvisionProtocol: {},
+		},
+	}
+	if _, err := p.service.AddDevice(device); err != nil {
+		return fmt.Errorf("unable to add device '%s' with profile '%s': %s", deviceName, profileName, err.Error())
+	}
+
+	// The device becomes available once metadata notified the service about it
+	deadline := time.Now().Add(p.timeout)
+	for {
+		if _, err := p.service.GetDeviceByName(deviceName); err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("device '%s' added but not available after %s", deviceName, p.timeout)
+		}
+		time.Sleep(autoProvisionPollInterval)
+	}
+}
+
+func (p *deviceProvisioner) allowed(deviceName string) bool {
+	for _, expression := range p.allowedNames {
+		if expression.MatchString(deviceName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// profileName selects the profile from the request header, the query parameter or
+// the first matching profile rule, in that order
+func (p *deviceProvisioner) profileName(deviceName string, request *http.Request) string {
+	if profileName := request.Header.Get(profileRequestHeader); profileName != "" {
+		return profileName
+	}
+	if profileName := request.URL.Query().Get(profileQueryParam); profileName != "" {
+		return profileName
+	}
+	for _, rule := range p.rules {
+		if rule.pattern.MatchString(deviceName) {
+			return rule.profileName
+		}
+	}
+
+	return ""
+}
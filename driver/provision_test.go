@@ -0,0 +1,147 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvision(t *testing.T) {
+	config := AutoProvisionConfig{
+		Enabled:      true,
+		AllowedNames: []string{"^sensor-[0-9]+$", "^thermostat-.*"},
+		ProfileRules: []ProfileRule{
+			{NamePattern: "^thermostat-", ProfileName: "thermostat"},
+		},
+		Timeout: "1s",
+	}
+	require.NoError(t, config.Validate())
+
+	tests := []struct {
+		Name            string
+		DeviceName      string
+		Target          string
+		ProfileHeader   string
+		ExpectedProfile string
+		NotAllowed      bool
+	}{
+		{"Profile from header", "sensor-1", "/api/v3/resource/sensor-1/temperature?profile=query-profile", "header-profile", "header-profile", false},
+		{"Profile from query", "sensor-2", "/api/v3/resource/sensor-2/temperature?profile=query-profile", "", "query-profile", false},
+		{"Profile from rule", "thermostat-7", "/api/v3/resource/thermostat-7/temperature", "", "thermostat", false},
+		{"No profile selected", "sensor-3", "/api/v3/resource/sensor-3/temperature", "", "", true},
+		{"Name not allowed", "camera-1", "/api/v3/resource/camera-1/temperature?profile=query-profile", "", "", true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.Name, func(t *testing.T) {
+			service := &mocks.DeviceServiceSDK{}
+			service.On("Name").Return("device-rest")
+			service.On("GetDeviceByName", testCase.DeviceName).Return(models.Device{}, errors.New("not found")).Once()
+			service.On("GetDeviceByName", testCase.DeviceName).Return(models.Device{Name: testCase.DeviceName}, nil)
+			service.On("AddDevice", mock.Anything).Return("id", nil)
+			provisioner := newDeviceProvisioner(service, config)
+
+			request := httptest.NewRequest(http.MethodPost, testCase.Target, nil)
+			if testCase.ProfileHeader != "" {
+				request.Header.Set(profileRequestHeader, testCase.ProfileHeader)
+			}
+
+			err := provisioner.provision(testCase.DeviceName, request)
+			if testCase.NotAllowed {
+				require.ErrorIs(t, err, errProvisioningNotAllowed)
+				service.AssertNotCalled(t, "AddDevice", mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			service.AssertCalled(t, "AddDevice", mock.MatchedBy(func(device models.Device) bool {
+				return device.Name == testCase.DeviceName && device.ProfileName == testCase.ExpectedProfile &&
+					device.ServiceName == "device-rest"
+			}))
+		})
+	}
+}
+
+func TestProvisionConcurrent(t *testing.T) {
+	config := AutoProvisionConfig{Enabled: true, AllowedNames: []string{"^sensor-[0-9]+$"}, Timeout: "1s"}
+	require.NoError(t, config.Validate())
+
+	adding := make(chan struct{}, 2)
+	release := make(chan struct{})
+	service := &mocks.DeviceServiceSDK{}
+	service.On("Name").Return("device-rest")
+	for _, deviceName := range []string{"sensor-1", "sensor-2"} {
+		service.On("GetDeviceByName", deviceName).Return(models.Device{}, errors.New("not found")).Once()
+		service.On("GetDeviceByName", deviceName).Return(models.Device{Name: deviceName}, nil)
+	}
+	service.On("AddDevice", mock.MatchedBy(func(device models.Device) bool { return device.Name == "sensor-1" })).
+		Run(func(mock.Arguments) {
+			adding <- struct{}{}
+			<-release
+		}).Return("id", nil)
+	service.On("AddDevice", mock.MatchedBy(func(device models.Device) bool { return device.Name == "sensor-2" })).Return("id", nil)
+	provisioner := newDeviceProvisioner(service, config)
+
+	provision := func(deviceName string) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			done <- provisioner.provision(deviceName, httptest.NewRequest(http.MethodPost, "/api/v3/resource/"+deviceName+"/temperature?profile=sensor", nil))
+		}()
+		return done
+	}
+
+	first := provision("sensor-1")
+	<-adding
+	second := provision("sensor-1")
+
+	// Provisioning another device doesn't wait for the blocked one
+	require.NoError(t, <-provision("sensor-2"))
+
+	close(release)
+	require.NoError(t, <-first)
+	require.NoError(t, <-second)
+	service.AssertNumberOfCalls(t, "AddDevice", 2)
+}
+
+func TestAutoProvisionConfigValidate(t *testing.T) {
+	valid := AutoProvisionConfig{Enabled: true, AllowedNames: []string{".*"}, Timeout: "5s"}
+	require.NoError(t, valid.Validate())
+
+	invalid := []AutoProvisionConfig{
+		{Enabled: true, Timeout: "5s"},
+		{Enabled: true, AllowedNames: []string{"("}, Timeout: "5s"},
+		{Enabled: true, AllowedNames: []string{".*"}, ProfileRules: []ProfileRule{{NamePattern: ".*"}}, Timeout: "5s"},
+		{Enabled: true, AllowedNames: []string{".*"}},
+		{Enabled: true, AllowedNames: []string{".*"}, Timeout: "0s"},
+		{Enabled: true, AllowedNames: []string{".*"}, Timeout: "soon"},
+	}
+	for _, config := range invalid {
+		assert.Error(t, config.Validate(), config)
+	}
+	assert.NoError(t, AutoProvisionConfig{}.Validate(), "disabled configuration isn't validated")
+
+	// Unknown devices have no secret to authenticate their first POST with
+	for _, ingestionAuth := range []string{IngestionAuthDeviceKey, IngestionAuthHMAC} {
+		config := CustomConfig{IngestionAuth: ingestionAuth, AutoProvision: valid,
+			HMAC: HMACConfig{Algorithm: hmacAlgorithmSHA256, SignatureHeader: "X-Signature", TimestampHeader: "X-Timestamp", ReplayWindow: "5m"}}
+		assert.ErrorContains(t, config.Validate(), "invalid AutoProvision", ingestionAuth)
+	}
+	assert.NoError(t, CustomConfig{IngestionAuth: IngestionAuthEdgeX, AutoProvision: valid}.Validate())
+}
+
+func TestProvisionDisabled(t *testing.T) {
+	assert.Nil(t, newDeviceProvisioner(&mocks.DeviceServiceSDK{}, AutoProvisionConfig{AllowedNames: []string{".*"}}))
+}
@@ -0,0 +1,75 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+// readLimiters bound the reads sent to each device at the same time, the reads of
+// concurrent commands of a device share its limit
+type readLimiters struct {
+	limits map[string]chan struct{}
+	mutex  sync.Mutex
+}
+
+func newReadLimiters() *readLimiters {
+	return &readLimiters{limits: map[string]chan struct{}{}}
+}
+
+// get returns the semaphore of the device, which is replaced when the limit changed.
+// Reads holding the replaced semaphore release it when they're done.
+func (l *readLimiters) get(deviceName string, limit int) chan struct{} {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	limit = max(limit, 1)
+	semaphore, ok := l.limits[deviceName]
+	if !ok || cap(semaphore) != limit {
+		semaphore = make(chan struct{}, limit)
+		l.limits[deviceName] = semaphore
+	}
+
+	return semaphore
+}
+
+func (l *readLimiters) remove(deviceName string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	delete(l.limits, deviceName)
+}
+
+// maxConcurrentReads returns the read limit of the device, its protocol property
+// overrides the service's setting
+func (driver *RestDriver) maxConcurrentReads(protocolParams RestProtocolParams) int {
+	if protocolParams.maxConcurrentReads > 0 {
+		return protocolParams.maxConcurrentReads
+	}
+
+	return driver.config.AppCustom.MaxConcurrentReads
+}
+
+// readError combines the errors of the resources that failed to read. The errors
+// name their resource when the command reads several resources.
+func readError(reqs []dsModels.CommandRequest, errs []error) error {
+	if len(reqs) == 1 {
+		return errs[0]
+	}
+
+	var failed []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", reqs[i].DeviceResourceName, err))
+		}
+	}
+
+	return errors.Join(failed...)
+}
@@ -0,0 +1,183 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+
+	"github.com/spf13/cast"
+)
+
+const (
+	// placeholderResourceName is replaced by the resource name in URL path templates
+	placeholderResourceName = "resourceName"
+
+	// contentTypeOctetStream is the content type of Binary writes of resources
+	// without media type
+	contentTypeOctetStream = "application/octet-stream"
+)
+
+// urlPlaceholder matches the placeholders of URL path templates, e.g. {zone}
+var urlPlaceholder = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// requestMethods are the HTTP methods resources may select for reads and writes
+var requestMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// requestMethod returns the HTTP method selected by the resource attribute, or the
+// default method when the resource selects none
+func requestMethod(attributes map[string]any, attribute string, defaultMethod string) (string, error) {
+	value, ok := attributes[attribute]
+	if !ok {
+		return defaultMethod, nil
+	}
+
+	method := strings.ToUpper(fmt.Sprint(value))
+	if !slices.Contains(requestMethods, method) {
+		return "", fmt.Errorf("invalid %s '%v', must be one of %s", attribute, value, strings.Join(requestMethods, ", "))
+	}
+
+	return method, nil
+}
+
+// readRequestBody returns the body a resource sends with read requests and its content
+// type. Without explicit content type the body is sent as JSON if it is valid JSON.
+func readRequestBody(attributes map[string]any) (body string, contentType string, ok bool) {
+	value, ok := attributes[ReadBody]
+	if !ok {
+		return "", "", false
+	}
+
+	switch value := value.(type) {
+	case string:
+		body = value
+	default:
+		// Object attributes are sent as JSON
+		buf, err := json.Marshal(value)
+		if err != nil {
+			body = fmt.Sprint(value)
+		} else {
+			body = string(buf)
+		}
+	}
+
+	if contentType, ok := attributes[ReadContentType]; ok {
+		return body, fmt.Sprint(contentType), true
+	}
+	if json.Valid([]byte(body)) {
+		return body, common.ContentTypeJSON, true
+	}
+
+	return body, common.ContentTypeText, true
+}
+
+// baseURL returns the scheme, host and port of the device
+func (params RestProtocolParams) baseURL() string {
+	return fmt.Sprintf("%s://%s", params.scheme, net.JoinHostPort(params.host, params.port))
+}
+
+// pathURL returns the URL of the path on the device, with or without leading slash
+func (params RestProtocolParams) pathURL(path string) string {
+	return params.baseURL() + "/" + strings.TrimPrefix(path, "/")
+}
+
+// requestURL returns the URL of a resource. Without URL path template it's the
+// device's path followed by the resource name. The query of the command is only
+// appended when there is one.
+func requestURL(protocolParams RestProtocolParams, resourceName string, attributes map[string]any) (string, error) {
+	var path string
+	if template, ok := attributes[URLPath]; ok {
+		var err error
+		if path, err = expandURLPath(fmt.Sprint(template), protocolParams, resourceName, attributes); err != nil {
+			return "", err
+		}
+		// Relative templates are below the device's path
+		if !strings.HasPrefix(path, "/") && protocolParams.path != "" {
+			path = strings.TrimSuffix(protocolParams.path, "/") + "/" + path
+		}
+	} else {
+		path = url.PathEscape(resourceName)
+		if protocolParams.path != "" {
+			path = strings.TrimSuffix(protocolParams.path, "/") + "/" + path
+		}
+	}
+
+	uri := protocolParams.pathURL(path)
+	if query := cast.ToString(attributes[URLRawQuery]); query != "" {
+		uri += "?" + query
+	}
+
+	return uri, nil
+}
+
+// expandURLPath replaces the placeholders of the URL path template with the escaped
+// resource name, request attributes or protocol properties of the same name, in this
+// order of precedence
+func expandURLPath(template string, protocolParams RestProtocolParams, resourceName string, attributes map[string]any) (string, error) {
+	var missing []string
+	path := urlPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, ok := placeholderValue(placeholder[1:len(placeholder)-1], protocolParams, resourceName, attributes)
+		if !ok {
+			missing = append(missing, placeholder)
+			return placeholder
+		}
+		return url.PathEscape(fmt.Sprint(value))
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%s '%s' has unresolved placeholders %s", URLPath, template, strings.Join(missing, ", "))
+	}
+
+	return path, nil
+}
+
+// placeholderValue resolves a template placeholder to the resource name, or to the
+// request attribute or protocol property of the same name
+func placeholderValue(name string, protocolParams RestProtocolParams, resourceName string, attributes map[string]any) (any, bool) {
+	if name == placeholderResourceName {
+		return resourceName, true
+	}
+	if value, ok := attributes[name]; ok {
+		return value, true
+	}
+	value, ok := protocolParams.properties[name]
+
+	return value, ok
+}
+
+// binaryWriteBody returns the bytes a Binary write sends. String values are decoded
+// as base64 when the resource selects it, otherwise they are sent as they are.
+func binaryWriteBody(value any, attributes map[string]any) ([]byte, error) {
+	switch value := value.(type) {
+	case []byte:
+		return value, nil
+	case string:
+		decode, err := cast.ToBoolE(attributes[Base64Decode])
+		if err != nil {
+			return nil, fmt.Errorf("%s is not bool type", Base64Decode)
+		}
+		if !decode {
+			return []byte(value), nil
+		}
+		data, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 value: %v", err)
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("binary value must be bytes or string, not %T", value)
+	}
+}
@@ -0,0 +1,123 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestMethod(t *testing.T) {
+	var method, body, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, _ := io.ReadAll(r.Body)
+		method, body, contentType = r.Method, string(buf), r.Header.Get(common.ContentType)
+		_, _ = w.Write([]byte("21.5"))
+	}))
+	defer server.Close()
+
+	resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	driver, _ := newTestDriver(t, CustomConfig{}, "device", resource)
+	protocols := restProtocols(t, server, nil)
+	value, err := sdkModels.NewCommandValue(resource.Name, common.ValueTypeFloat64, 21.5)
+	require.NoError(t, err)
+
+	readTests := []struct {
+		name                string
+		attributes          map[string]any
+		expectedMethod      string
+		expectedBody        string
+		expectedContentType string
+	}{
+		{"default", nil, http.MethodGet, "", ""},
+		{"post with JSON body", map[string]any{ReadMethod: "post", ReadBody: `{"sensor":"t1"}`}, http.MethodPost, `{"sensor":"t1"}`, common.ContentTypeJSON},
+		{"post with object body", map[string]any{ReadMethod: "POST", ReadBody: map[string]any{"sensor": "t1"}}, http.MethodPost, `{"sensor":"t1"}`, common.ContentTypeJSON},
+		{"post with text body", map[string]any{ReadMethod: "POST", ReadBody: "sensor=t1"}, http.MethodPost, "sensor=t1", common.ContentTypeText},
+		{"explicit content type", map[string]any{ReadMethod: "POST", ReadBody: "sensor=t1", ReadContentType: "application/x-www-form-urlencoded"}, http.MethodPost, "sensor=t1", "application/x-www-form-urlencoded"},
+	}
+	for _, testCase := range readTests {
+		t.Run("read "+testCase.name, func(t *testing.T) {
+			reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64, Attributes: testCase.attributes}}
+			responses, err := driver.HandleReadCommands("device", protocols, reqs)
+			require.NoError(t, err)
+			assert.Equal(t, 21.5, responses[0].Value)
+			assert.Equal(t, testCase.expectedMethod, method)
+			assert.Equal(t, testCase.expectedBody, body)
+			assert.Equal(t, testCase.expectedContentType, contentType)
+		})
+	}
+
+	writeTests := []struct {
+		name           string
+		attributes     map[string]any
+		expectedMethod string
+	}{
+		{"default", nil, http.MethodPut},
+		{"post", map[string]any{WriteMethod: "POST"}, http.MethodPost},
+		{"patch", map[string]any{WriteMethod: "patch"}, http.MethodPatch},
+	}
+	for _, testCase := range writeTests {
+		t.Run("write "+testCase.name, func(t *testing.T) {
+			reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64, Attributes: testCase.attributes}}
+			require.NoError(t, driver.HandleWriteCommands("device", protocols, reqs, []*sdkModels.CommandValue{value}))
+			assert.Equal(t, testCase.expectedMethod, method)
+			assert.Equal(t, "21.5", body)
+		})
+	}
+
+	reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64, Attributes: map[string]any{ReadMethod: "CONNECT"}}}
+	_, err = driver.HandleReadCommands("device", protocols, reqs)
+	assert.Error(t, err)
+}
+
+func TestRequestURL(t *testing.T) {
+	protocolParams := RestProtocolParams{
+		scheme:     schemeHTTP,
+		host:       "localhost",
+		port:       "5000",
+		path:       "api",
+		properties: map[string]any{"zone": "north wing", "site": "plant/1"},
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		attributes    map[string]any
+		expectedURL   string
+		expectedError bool
+	}{
+		{"default", "api", nil, "http://localhost:5000/api/temperature", false},
+		{"default without path", "", nil, "http://localhost:5000/temperature", false},
+		{"default with query", "api", map[string]any{URLRawQuery: "unit=C"}, "http://localhost:5000/api/temperature?unit=C", false},
+		{"empty query", "api", map[string]any{URLRawQuery: ""}, "http://localhost:5000/api/temperature", false},
+		{"absolute template", "api", map[string]any{URLPath: "/v1/zones/{zone}/setpoint"}, "http://localhost:5000/v1/zones/north%20wing/setpoint", false},
+		{"relative template", "api", map[string]any{URLPath: "sites/{site}/{resourceName}"}, "http://localhost:5000/api/sites/plant%2F1/temperature", false},
+		{"attribute overrides property", "api", map[string]any{URLPath: "/zones/{zone}", "zone": "south"}, "http://localhost:5000/zones/south", false},
+		{"template with query", "", map[string]any{URLPath: "/zones/{zone}", URLRawQuery: "a=1&b=2"}, "http://localhost:5000/zones/north%20wing?a=1&b=2", false},
+		{"unresolved placeholder", "api", map[string]any{URLPath: "/zones/{floor}"}, "", true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			protocolParams.path = testCase.path
+			uri, err := requestURL(protocolParams, "temperature", testCase.attributes)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedURL, uri)
+		})
+	}
+}
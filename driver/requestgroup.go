@@ -0,0 +1,136 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	dsModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+
+	"github.com/spf13/cast"
+)
+
+// commandGroup holds the indices of the command requests sent with a single request.
+// The name is the request group, or the resource name of requests sent on their own.
+type commandGroup struct {
+	name    string
+	indices []int
+}
+
+// commandGroups groups the command requests by their request group attribute, in the
+// order of their first request. Requests without group are sent on their own.
+func commandGroups(reqs []dsModels.CommandRequest) []commandGroup {
+	var groups []commandGroup
+	groupIndex := map[string]int{}
+	for i, req := range reqs {
+		name := cast.ToString(req.Attributes[RequestGroup])
+		if name == "" {
+			groups = append(groups, commandGroup{name: req.DeviceResourceName, indices: []int{i}})
+			continue
+		}
+		if index, ok := groupIndex[name]; ok {
+			groups[index].indices = append(groups[index].indices, i)
+			continue
+		}
+		groupIndex[name] = len(groups)
+		groups = append(groups, commandGroup{name: name, indices: []int{i}})
+	}
+
+	return groups
+}
+
+// writeResources writes the values of resources of the device with a single request,
+// so that the device applies them at once. The values are combined into one JSON
+// object with a field per resource, named by its writeField attribute or else by the
+// resource name. The request is created from the attributes of the first resource.
+func (driver *RestDriver) writeResources(deviceName string, protocolParams RestProtocolParams, name string, reqs []dsModels.CommandRequest, params []*dsModels.CommandValue) error {
+	attributes := reqs[0].Attributes
+
+	// Form URI from the end device parameters, the group's URL path template and
+	// query parameters received in the request.
+	uri, err := requestURL(protocolParams, name, attributes)
+	if err != nil {
+		return err
+	}
+
+	// Groups may write with another method than PUT
+	method, err := requestMethod(attributes, WriteMethod, http.MethodPut)
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[string]any, len(reqs))
+	for i, req := range reqs {
+		deviceResource, ok := driver.sdk.DeviceResource(deviceName, req.DeviceResourceName)
+		if !ok {
+			return fmt.Errorf("incoming writing ignored. resource '%s' not found", req.DeviceResourceName)
+		}
+
+		field := req.DeviceResourceName
+		if writeField, ok := req.Attributes[WriteField]; ok {
+			field = fmt.Sprint(writeField)
+		}
+		if _, ok := fields[field]; ok {
+			return fmt.Errorf("%s request data is not valid: field '%s' of resource %s is used by another resource of %s '%s'", method, field, req.DeviceResourceName, RequestGroup, name)
+		}
+
+		valueType := deviceResource.Properties.ValueType
+		switch valueType {
+		case common.ValueTypeBinary:
+			return fmt.Errorf("%s is not supported for %s resources", RequestGroup, valueType)
+		case common.ValueTypeObject:
+		default:
+			if _, isArray := arrayElementTypes[valueType]; !isArray {
+				if _, err := validateCommandValue(deviceResource, params[i].Value, valueType, common.ContentTypeText); err != nil {
+					return fmt.Errorf("%s request data of %s is not valid", method, req.DeviceResourceName)
+				}
+			}
+		}
+		// Marshal the value like array writes, so that byte arrays stay numbers
+		value, err := marshalArrayValue(params[i].Value)
+		if err != nil {
+			return fmt.Errorf("%s request data of %s is not valid: %v", method, req.DeviceResourceName, err)
+		}
+		fields[field] = json.RawMessage(value)
+	}
+
+	var request *http.Request
+	if bodyTemplate, ok := attributes[WriteBody]; ok {
+		// Groups with a body template wrap the combined values in the device's envelope
+		data := writeBodyData{
+			Value:      fields,
+			Device:     deviceName,
+			Resource:   name,
+			Properties: protocolParams.properties,
+			Attributes: attributes,
+		}
+		body, contentType, err := renderWriteBody(bodyTemplate, data, protocolParams)
+		if err != nil {
+			return fmt.Errorf("%s request data is not valid: %v", method, err)
+		}
+		if request, err = http.NewRequest(method, uri, strings.NewReader(body)); err != nil {
+			return fmt.Errorf("%s request creation failed", method)
+		}
+		request.Header.Set(common.ContentType, contentType)
+	} else {
+		body, err := json.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("%s request data is not valid: %v", method, err)
+		}
+		if request, err = http.NewRequest(method, uri, bytes.NewReader(body)); err != nil {
+			return fmt.Errorf("%s request creation failed", method)
+		}
+		request.Header.Set(common.ContentType, common.ContentTypeJSON)
+	}
+
+	return driver.sendWriteRequest(deviceName, protocolParams, request, attributes)
+}
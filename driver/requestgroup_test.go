@@ -0,0 +1,164 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadGroups(t *testing.T) {
+	reqs := []sdkModels.CommandRequest{
+		{DeviceResourceName: "temperature", Attributes: map[string]any{RequestGroup: "status"}},
+		{DeviceResourceName: "image"},
+		{DeviceResourceName: "humidity", Attributes: map[string]any{RequestGroup: "status"}},
+		{DeviceResourceName: "firmware", Attributes: map[string]any{RequestGroup: "info"}},
+	}
+
+	assert.Equal(t, []commandGroup{
+		{name: "status", indices: []int{0, 2}},
+		{name: "image", indices: []int{1}},
+		{name: "info", indices: []int{3}},
+	}, commandGroups(reqs))
+}
+
+func TestHandleReadCommandsRequestGroup(t *testing.T) {
+	var mutex sync.Mutex
+	paths := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		paths[r.URL.Path]++
+		mutex.Unlock()
+
+		if r.URL.Path == "/api/image" {
+			_, _ = w.Write([]byte("42"))
+			return
+		}
+		w.Header().Set(common.ContentType, common.ContentTypeJSON)
+		_, _ = w.Write([]byte(`{"temperature":21.5,"humidity":40,"on":true}`))
+	}))
+	defer server.Close()
+
+	temperature := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	humidity := models.DeviceResource{Name: "humidity", Properties: models.ResourceProperties{ValueType: common.ValueTypeInt32}}
+	on := models.DeviceResource{Name: "on", Properties: models.ResourceProperties{ValueType: common.ValueTypeBool}}
+	pressure := models.DeviceResource{Name: "pressure", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	image := models.DeviceResource{Name: "image", Properties: models.ResourceProperties{ValueType: common.ValueTypeInt32}}
+	driver, _ := newTestDriver(t, CustomConfig{MaxConcurrentReads: 4}, "device", temperature, humidity, on, pressure, image)
+	protocols := restProtocols(t, server, nil)
+
+	groupRequest := func(resource models.DeviceResource, attributes map[string]any) sdkModels.CommandRequest {
+		attributes[JSONPath] = "$." + resource.Name
+		return sdkModels.CommandRequest{DeviceResourceName: resource.Name, Type: resource.Properties.ValueType, Attributes: attributes}
+	}
+	reqs := []sdkModels.CommandRequest{
+		groupRequest(temperature, map[string]any{RequestGroup: "status"}),
+		{DeviceResourceName: image.Name, Type: common.ValueTypeInt32},
+		groupRequest(humidity, map[string]any{RequestGroup: "status"}),
+		groupRequest(on, map[string]any{RequestGroup: "state", URLPath: "/v2/status"}),
+	}
+	responses, err := driver.HandleReadCommands("device", protocols, reqs)
+	require.NoError(t, err)
+	require.Len(t, responses, 4)
+	assert.Equal(t, 21.5, responses[0].Value)
+	assert.Equal(t, int32(42), responses[1].Value)
+	assert.Equal(t, int32(40), responses[2].Value)
+	assert.Equal(t, true, responses[3].Value)
+	assert.Equal(t, map[string]int{"/api/status": 1, "/api/image": 1, "/v2/status": 1}, paths, "each group must be read with a single request")
+
+	// A resource missing in the shared response fails on its own
+	reqs = []sdkModels.CommandRequest{
+		groupRequest(temperature, map[string]any{RequestGroup: "status"}),
+		groupRequest(pressure, map[string]any{RequestGroup: "status"}),
+	}
+	responses, err = driver.HandleReadCommands("device", protocols, reqs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pressure: ")
+	assert.NotContains(t, err.Error(), "temperature: ")
+	assert.Equal(t, 21.5, responses[0].Value)
+	assert.Nil(t, responses[1])
+}
+
+func TestHandleWriteCommandsRequestGroup(t *testing.T) {
+	var mutex sync.Mutex
+	bodies := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mutex.Lock()
+		bodies[r.Method+" "+r.URL.Path] = string(body)
+		mutex.Unlock()
+	}))
+	defer server.Close()
+
+	setpoint := models.DeviceResource{Name: "setpoint", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	mode := models.DeviceResource{Name: "mode", Properties: models.ResourceProperties{ValueType: common.ValueTypeString}}
+	levels := models.DeviceResource{Name: "levels", Properties: models.ResourceProperties{ValueType: common.ValueTypeUint8Array}}
+	fan := models.DeviceResource{Name: "fan", Properties: models.ResourceProperties{ValueType: common.ValueTypeBool}}
+	small := models.DeviceResource{Name: "small", Properties: models.ResourceProperties{ValueType: common.ValueTypeInt8}}
+	driver, _ := newTestDriver(t, CustomConfig{}, "device", setpoint, mode, levels, fan, small)
+	protocols := restProtocols(t, server, nil)
+
+	value := func(resource models.DeviceResource, v any) *sdkModels.CommandValue {
+		commandValue, err := sdkModels.NewCommandValue(resource.Name, resource.Properties.ValueType, v)
+		require.NoError(t, err)
+		return commandValue
+	}
+	request := func(resource models.DeviceResource, attributes map[string]any) sdkModels.CommandRequest {
+		return sdkModels.CommandRequest{DeviceResourceName: resource.Name, Type: resource.Properties.ValueType, Attributes: attributes}
+	}
+
+	reqs := []sdkModels.CommandRequest{
+		request(setpoint, map[string]any{RequestGroup: "config", URLPath: "/v1/config", WriteMethod: "POST", WriteField: "target"}),
+		request(fan, nil),
+		request(mode, map[string]any{RequestGroup: "config"}),
+		request(levels, map[string]any{RequestGroup: "config"}),
+	}
+	params := []*sdkModels.CommandValue{value(setpoint, 21.5), value(fan, true), value(mode, "eco"), value(levels, []uint8{1, 2})}
+	err := driver.HandleWriteCommands("device", protocols, reqs, params)
+	require.NoError(t, err)
+	require.Len(t, bodies, 2, "the group must be written with a single request")
+	assert.JSONEq(t, `{"target":21.5,"mode":"eco","levels":[1,2]}`, bodies["POST /v1/config"])
+	assert.Equal(t, "true", bodies["PUT /api/fan"])
+
+	// The group's body template wraps the combined values
+	clear(bodies)
+	reqs = []sdkModels.CommandRequest{
+		request(setpoint, map[string]any{RequestGroup: "config", WriteBody: map[string]any{"settings": "{value}", "device": "{resourceName}"}}),
+		request(mode, map[string]any{RequestGroup: "config"}),
+	}
+	err = driver.HandleWriteCommands("device", protocols, reqs, []*sdkModels.CommandValue{value(setpoint, 21.5), value(mode, "eco")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"settings":{"setpoint":21.5,"mode":"eco"},"device":"config"}`, bodies["PUT /api/config"])
+
+	// Nothing is written when a value of the group is invalid
+	clear(bodies)
+	reqs = []sdkModels.CommandRequest{
+		request(setpoint, map[string]any{RequestGroup: "config"}),
+		request(small, map[string]any{RequestGroup: "config"}),
+	}
+	invalid := &sdkModels.CommandValue{DeviceResourceName: small.Name, Type: common.ValueTypeInt8, Value: "300"}
+	err = driver.HandleWriteCommands("device", protocols, reqs, []*sdkModels.CommandValue{value(setpoint, 21.5), invalid})
+	assert.Error(t, err)
+	assert.Empty(t, bodies)
+
+	reqs = []sdkModels.CommandRequest{
+		request(setpoint, map[string]any{RequestGroup: "config", WriteField: "value"}),
+		request(mode, map[string]any{RequestGroup: "config", WriteField: "value"}),
+	}
+	err = driver.HandleWriteCommands("device", protocols, reqs, []*sdkModels.CommandValue{value(setpoint, 21.5), value(mode, "eco")})
+	assert.Error(t, err, "fields must be unique")
+	assert.Empty(t, bodies)
+}
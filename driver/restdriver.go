@@ -32,6 +32,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces"
@@ -43,9 +44,21 @@ import (
 	"github.com/spf13/cast"
 )
 
+// maxDrainedResponseSize limits how much of an unused response body is read, larger
+// bodies are cheaper to discard with their connection than to drain
+const maxDrainedResponseSize = 64 << 10
+
 type RestDriver struct {
-	sdk    interfaces.DeviceServiceSDK
-	logger logger.LoggingClient
+	sdk      interfaces.DeviceServiceSDK
+	logger   logger.LoggingClient
+	config   *ServiceConfig
+	clients  *clientPool
+	metrics  *commandMetrics
+	breakers *circuitBreakers
+	tokens   *tokenCache
+	digests  *digestSessions
+	sessions *deviceSessions
+	reads    *readLimiters
 }
 
 // RestProtocolParams holds end device protocol parameters
@@ -53,6 +66,39 @@ type RestProtocolParams struct {
 	host string
 	port string
 	path string
+	// scheme is either http or https
+	scheme string
+	// properties are the REST protocol properties, resolving URL path templates
+	properties map[string]any
+	// httpClient holds the HTTP client settings the device overrides
+	httpClient HTTPClientConfig
+	// retry holds the retry settings the device overrides
+	retry retryOverrides
+	// TLS settings of https devices
+	tlsSecretName         string
+	tlsServerName         string
+	tlsInsecureSkipVerify bool
+	// headers are sent with every request to the device
+	headers map[string]string
+	// maxConcurrentReads overrides the service's read limit when set
+	maxConcurrentReads int
+	// authMethod selects how requests authenticate with the end device, using the
+	// credentials of the auth secret
+	authMethod     string
+	authSecretName string
+	apiKeyHeader   string
+	// OAuth2 settings of devices authenticating with access tokens
+	tokenURL         string
+	oauth2Scopes     string
+	oauth2ClientAuth string
+	// Login settings of devices authenticating with a session
+	loginURL         string
+	loginBody        string
+	loginContentType string
+	sessionCookie    string
+	csrfHeader       string
+	csrfField        string
+	sessionLifetime  time.Duration
 }
 
 // Initialize performs protocol-specific initialization for the device
@@ -61,20 +107,40 @@ func (driver *RestDriver) Initialize(sdk interfaces.DeviceServiceSDK) error {
 	driver.logger = sdk.LoggingClient()
 	driver.sdk = sdk
 
+	driver.config = &ServiceConfig{}
+	if err := sdk.LoadCustomConfig(driver.config, CustomConfigSectionName); err != nil {
+		return fmt.Errorf("unable to load '%s' custom configuration: %s", CustomConfigSectionName, err.Error())
+	}
+
+	if err := driver.config.AppCustom.Validate(); err != nil {
+		return fmt.Errorf("'%s' custom configuration validation failed: %s", CustomConfigSectionName, err.Error())
+	}
+
+	driver.clients = newClientPool()
+	driver.metrics = newCommandMetrics()
+	driver.breakers = newCircuitBreakers()
+	driver.tokens = newTokenCache()
+	driver.digests = newDigestSessions()
+	driver.sessions = newDeviceSessions()
+	driver.reads = newReadLimiters()
+
 	return nil
 }
 
 func (driver *RestDriver) Start() error {
-	handler := NewRestHandler(driver.sdk)
+	if err := driver.metrics.register(driver.sdk); err != nil {
+		driver.logger.Warnf("Command metrics not available: %s", err.Error())
+	}
+
+	handler := NewRestHandler(driver.sdk, driver.config.AppCustom)
 	return handler.Start()
 }
 
 // HandleReadCommands triggers a protocol Read operation for the specified device.
+// The resources are read concurrently, at most MaxConcurrentReads of the device at a
+// time. The readings are returned in the order of the requests, along with the
+// errors of the resources that failed to read.
 func (driver *RestDriver) HandleReadCommands(deviceName string, protocols map[string]models.ProtocolProperties, reqs []dsModels.CommandRequest) (responses []*dsModels.CommandValue, err error) {
-	var reading interface{}
-	var val interface{}
-	var result = &dsModels.CommandValue{}
-	var uri string
 	var protocolParams RestProtocolParams
 	responses = make([]*dsModels.CommandValue, len(reqs))
 
@@ -86,108 +152,212 @@ func (driver *RestDriver) HandleReadCommands(deviceName string, protocols map[st
 		return nil, fmt.Errorf("device parameters missing :%s", err.Error())
 	}
 
-	// Now, we have got required end device information, its time to create GET request
-	for i, req := range reqs {
-		// First get device resource instance, needed during validation of the
-		// response data received from the end device later.
-		// RunningService returns the Service instance which is running.
-		// service.DeviceResource retrieves the specific DeviceResource instance
-		// from cache according to the Device name and Device Resource name
-		deviceResource, ok := driver.sdk.DeviceResource(deviceName, req.DeviceResourceName)
-		if !ok {
-			return nil, fmt.Errorf("resource not found")
-		}
+	// Now, we have got required end device information, its time to create GET
+	// requests. Resources of the same request group are read with a single
+	// request, each request is sent by its own goroutine once the device's limit
+	// allows it
+	limit := driver.reads.get(deviceName, driver.maxConcurrentReads(protocolParams))
+	errs := make([]error, len(reqs))
+	var wg sync.WaitGroup
+	for _, group := range commandGroups(reqs) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			groupReqs := make([]dsModels.CommandRequest, len(group.indices))
+			for i, index := range group.indices {
+				groupReqs[i] = reqs[index]
+			}
+			values, groupErrs := driver.readResources(deviceName, protocolParams, group.name, groupReqs)
+			for i, index := range group.indices {
+				responses[index], errs[index] = values[i], groupErrs[i]
+			}
+		}()
+	}
+	wg.Wait()
 
-		// Now get the query parameters received in the request.
-		// These needs to be sent to the end device
-		// Query parameters needs to be converted to string to append to the uri
-		reqParam := fmt.Sprint(req.Attributes[URLRawQuery])
+	return responses, readError(reqs, errs)
+}
 
-		// Form URI from the end device parameters and request parameters and
-		// query parameters. Omit uri prefix if it is empty
-		if protocolParams.path != "" {
-			uri = fmt.Sprintf("http://%s:%s/%s/%s?%s", protocolParams.host, protocolParams.port, protocolParams.path, req.DeviceResourceName, reqParam)
-		} else {
-			uri = fmt.Sprintf("http://%s:%s/%s?%s", protocolParams.host, protocolParams.port, req.DeviceResourceName, reqParam)
+// readResources reads resources of the device with a single request and returns
+// their readings, each resource takes its value of the shared response. The request
+// is created from the attributes of the first resource, name is the resource or
+// request group it's sent for.
+func (driver *RestDriver) readResources(deviceName string, protocolParams RestProtocolParams, name string, reqs []dsModels.CommandRequest) ([]*dsModels.CommandValue, []error) {
+	responses := make([]*dsModels.CommandValue, len(reqs))
+	errs := make([]error, len(reqs))
+
+	// First get device resource instances, needed during validation of the
+	// response data received from the end device later.
+	// RunningService returns the Service instance which is running.
+	// service.DeviceResource retrieves the specific DeviceResource instance
+	// from cache according to the Device name and Device Resource name
+	deviceResources := make([]models.DeviceResource, len(reqs))
+	found := false
+	for i, req := range reqs {
+		var ok bool
+		if deviceResources[i], ok = driver.sdk.DeviceResource(deviceName, req.DeviceResourceName); !ok {
+			errs[i] = fmt.Errorf("resource not found")
+			continue
 		}
-		driver.logger.Debugf("Sending REST Get command to uri = %v", uri)
+		found = true
+	}
+	if !found {
+		return responses, errs
+	}
 
-		// Now we have end device informationa and uri. This is enough to create
-		// GET request. For this first create http client instance.
-		// Then create http new request, this will not initiate request to end device
-		client := &http.Client{}
-		request, err := http.NewRequest(http.MethodGet, uri, nil)
-		if err != nil {
-			// handle error
-			return nil, fmt.Errorf("GET request creation failed")
+	resp, body, err := driver.sendReadRequest(deviceName, protocolParams, name, reqs[0].Attributes)
+	if err != nil {
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = err
+			}
 		}
+		return responses, errs
+	}
 
-		// Now, we have client instance and GET request instance
-		// Initiate GET request to end device
-		resp, err := client.Do(request)
-		if err != nil {
-			// handle error
-			return nil, fmt.Errorf("get request failed")
+	for i, req := range reqs {
+		if errs[i] == nil {
+			responses[i], errs[i] = driver.resourceReading(deviceResources[i], req, resp, body)
 		}
+	}
 
-		// GET request to end device success, Its time to parse the response received
-		// Return immediately if status code is > 299
-		// Ref: https://pkg.go.dev/net/http
-		if resp.StatusCode > 299 {
-			return nil, fmt.Errorf("get response failed with status code: %v", resp.StatusCode)
-		}
+	return responses, errs
+}
 
-		// Reached here, as the success response is received. Let's get
-		// response body to return as response to this read command request.
-		// Close response body once read from it
-		defer resp.Body.Close()
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return responses, fmt.Errorf("read command failed. Cmd:%v err:%v", req.DeviceResourceName, err)
-		}
+// sendReadRequest sends the read request of a resource or request group to the
+// device and returns the response with its body
+func (driver *RestDriver) sendReadRequest(deviceName string, protocolParams RestProtocolParams, name string, attributes map[string]any) (*http.Response, []byte, error) {
+	// Form URI from the end device parameters, the resource's URL path
+	// template and query parameters received in the request.
+	uri, err := requestURL(protocolParams, name, attributes)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		// We are going to validate received content type against the expected
-		// content type of device resource. For doing this get content type from
-		// GET response header.  Take response body as it is if device
-		// resource data type is binary or object. For other data types convert
-		// response body to string to use during validation of reponse
-		if deviceResource.Properties.ValueType == common.ValueTypeBinary ||
-			deviceResource.Properties.ValueType == common.ValueTypeObject {
-			reading = body
-		} else {
-			reading = string(body)
-		}
-		contentType := resp.Header.Get(common.ContentType)
+	// Resources may read with another method than GET, optionally with a body
+	method, err := requestMethod(attributes, ReadMethod, http.MethodGet)
+	if err != nil {
+		return nil, nil, err
+	}
+	driver.logger.Debugf("Sending REST %s command to uri = %v", method, uri)
 
-		val, err = validateCommandValue(deviceResource, reading, deviceResource.Properties.ValueType, contentType)
-		if err != nil {
-			return nil, fmt.Errorf("recevice response data is not valid")
+	// Now we have end device informationa and uri. This is enough to create
+	// the request. For this first get the http client of the device.
+	// Then create http new request, this will not initiate request to end device
+	client, err := driver.httpClient(deviceName, protocolParams)
+	if err != nil {
+		return nil, nil, fmt.Errorf("http client creation failed: %v", err)
+	}
+	var request *http.Request
+	if body, contentType, ok := readRequestBody(attributes); ok {
+		request, err = http.NewRequest(method, uri, strings.NewReader(body))
+		if err == nil {
+			request.Header.Set(common.ContentType, contentType)
 		}
+	} else {
+		request, err = http.NewRequest(method, uri, nil)
+	}
+	if err != nil {
+		// handle error
+		return nil, nil, fmt.Errorf("%s request creation failed", method)
+	}
+	if err := driver.setCustomHeaders(request, protocolParams, attributes); err != nil {
+		return nil, nil, fmt.Errorf("%s request headers failed: %v", method, err)
+	}
+	if err := driver.authenticate(deviceName, request, protocolParams); err != nil {
+		return nil, nil, fmt.Errorf("%s request authentication failed: %v", method, err)
+	}
+
+	// Now, we have client instance and GET request instance
+	// Initiate GET request to end device, retried according to the device's policy
+	resp, err := driver.sendAuthenticated(deviceName, client, request, protocolParams)
+	if errors.Is(err, errCircuitOpen) {
+		return nil, nil, err
+	}
+	if err != nil {
+		// handle error
+		return nil, nil, fmt.Errorf("get request failed")
+	}
+
+	// Close response body once read from it, so that the connection is released
+	// before the readings are created
+	defer resp.Body.Close()
+
+	// GET request to end device success, Its time to parse the response received
+	// Return immediately if status code is > 299
+	// Ref: https://pkg.go.dev/net/http
+	if resp.StatusCode > 299 {
+		return nil, nil, fmt.Errorf("get response failed with status code: %v", resp.StatusCode)
+	}
+
+	// Reached here, as the success response is received. Let's get
+	// response body to return as response to this read command request.
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read command failed. Cmd:%v err:%v", name, err)
+	}
 
-		// Now, we have valid response data. This needs to be sent as response to the read command request. Create a CommandValue according to the data type
-		result, err = dsModels.NewCommandValue(deviceResource.Name, deviceResource.Properties.ValueType, val)
+	return resp, body, nil
+}
+
+// resourceReading creates the reading of a resource from the response of its read request
+func (driver *RestDriver) resourceReading(deviceResource models.DeviceResource, req dsModels.CommandRequest, resp *http.Response, body []byte) (*dsModels.CommandValue, error) {
+	var err error
+	// We are going to validate received content type against the expected
+	// content type of device resource. For doing this get content type from
+	// GET response header.  Take response body as it is if device
+	// resource data type is binary or object. For other data types convert
+	// response body to string to use during validation of reponse
+	// Resources selecting a value of a JSON response take only this value.
+	var reading interface{}
+	contentType := resp.Header.Get(common.ContentType)
+	if path, ok := req.Attributes[JSONPath]; ok {
+		reading, contentType, err = jsonPathReading(body, fmt.Sprint(path), deviceResource.Properties.ValueType)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("read command failed. Cmd:%v err:%v", req.DeviceResourceName, err)
 		}
-		result.Origin = time.Now().UnixNano()
+	} else if deviceResource.Properties.ValueType == common.ValueTypeBinary ||
+		deviceResource.Properties.ValueType == common.ValueTypeObject {
+		reading = body
+	} else {
+		reading = string(body)
+	}
 
-		responses[i] = result
+	val, err := validateCommandValue(deviceResource, reading, deviceResource.Properties.ValueType, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("recevice response data is not valid")
 	}
 
-	return responses, nil
+	// Now, we have valid response data. This needs to be sent as response to the read command request. Create a CommandValue according to the data type
+	result, err := dsModels.NewCommandValue(deviceResource.Name, deviceResource.Properties.ValueType, val)
+	if err != nil {
+		return nil, err
+	}
+	result.Origin = time.Now().UnixNano()
+
+	// Use the origin reported by the end device if the resource selects one
+	origin, err := driver.config.AppCustom.responseOrigin(req.Attributes, resp, body)
+	if err != nil {
+		driver.logger.Warnf("Using service time as origin of %s reading: %s", req.DeviceResourceName, err.Error())
+	} else if origin > 0 {
+		result.Origin = origin
+	}
+
+	return result, nil
 }
 
 // HandleWriteCommands passes a slice of CommandRequest struct each representing
 // a ResourceOperation for a specific device resource.
 // Since the commands are actuation commands, params provide parameters for the
-// individual command.
+// individual command. Resources of the same request group are written with a
+// single request, so that the device applies them at once.
 func (driver *RestDriver) HandleWriteCommands(deviceName string, protocols map[string]models.ProtocolProperties, reqs []dsModels.CommandRequest,
 	params []*dsModels.CommandValue) error {
 
-	// Create http request variable to be used for creating new request
-	var request *http.Request
 	var err error
-	var uri string
 	var protocolParams RestProtocolParams
 	// To send request to any end device, first we need to know end device details.
 	// Such as end device IP address, port number on which REST server is running etc.
@@ -197,49 +367,106 @@ func (driver *RestDriver) HandleWriteCommands(deviceName string, protocols map[s
 		return fmt.Errorf("device parameters missing :%s", err.Error())
 	}
 
-	for i, req := range reqs {
-		// First get device resource instance, needed during validation of the
-		// data received in the write command request
-		// RunningService returns the Service instance which is running
-		// service.DeviceResource retrieves the specific DeviceResource instance
-		// from cache according to the Device name and Device Resource name
-		deviceResource, ok := driver.sdk.DeviceResource(deviceName, req.DeviceResourceName)
-		if !ok {
-			return fmt.Errorf("incoming writing ignored. resource '%s' not found", req.DeviceResourceName)
+	for _, group := range commandGroups(reqs) {
+		first := group.indices[0]
+		if cast.ToString(reqs[first].Attributes[RequestGroup]) == "" {
+			err = driver.writeResource(deviceName, protocolParams, reqs[first], params[first])
+		} else {
+			groupReqs := make([]dsModels.CommandRequest, len(group.indices))
+			groupParams := make([]*dsModels.CommandValue, len(group.indices))
+			for i, index := range group.indices {
+				groupReqs[i], groupParams[i] = reqs[index], params[index]
+			}
+			err = driver.writeResources(deviceName, protocolParams, group.name, groupReqs, groupParams)
 		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-		// Now get the query parameters received in the request.
-		// These needs to be sent to the end device
-		// Query parameters needs to be converted to string to append to the uri
-		reqParam := fmt.Sprint(req.Attributes[URLRawQuery])
+// writeResource writes the value of a resource of the device with its own request
+func (driver *RestDriver) writeResource(deviceName string, protocolParams RestProtocolParams, req dsModels.CommandRequest, param *dsModels.CommandValue) error {
+	// Create http request variable to be used for creating new request
+	var request *http.Request
 
-		// Form URI from the end device parameters and request parameters and
-		// query parameters. Omit uri prefix if it is empty
-		if protocolParams.path != "" {
-			uri = fmt.Sprintf("http://%s:%s/%s/%s?%s", protocolParams.host, protocolParams.port, protocolParams.path, req.DeviceResourceName, reqParam)
-		} else {
-			uri = fmt.Sprintf("http://%s:%s/%s?%s", protocolParams.host, protocolParams.port, req.DeviceResourceName, reqParam)
+	// First get device resource instance, needed during validation of the
+	// data received in the write command request
+	// RunningService returns the Service instance which is running
+	// service.DeviceResource retrieves the specific DeviceResource instance
+	// from cache according to the Device name and Device Resource name
+	deviceResource, ok := driver.sdk.DeviceResource(deviceName, req.DeviceResourceName)
+	if !ok {
+		return fmt.Errorf("incoming writing ignored. resource '%s' not found", req.DeviceResourceName)
+	}
+
+	// Form URI from the end device parameters, the resource's URL path
+	// template and query parameters received in the request.
+	uri, err := requestURL(protocolParams, req.DeviceResourceName, req.Attributes)
+	if err != nil {
+		return err
+	}
+
+	// Resources may write with another method than PUT
+	method, err := requestMethod(req.Attributes, WriteMethod, http.MethodPut)
+	if err != nil {
+		return err
+	}
+
+	// Its time to form payload to be sent to end device.
+	// For this fisrt get the data received in the write command request
+	// This data is validated against the expected value type of device resource
+	// With the data and uri create new http request
+	// And, set the content type header for the request
+	reading := param.Value
+	valueType := deviceResource.Properties.ValueType
+	if bodyTemplate, ok := req.Attributes[WriteBody]; ok {
+		// Resources with a body template wrap the value in the device's envelope
+		switch valueType {
+		case common.ValueTypeBinary:
+			return fmt.Errorf("%s is not supported for %s resources", WriteBody, valueType)
+		case common.ValueTypeObject:
+		default:
+			if _, isArray := arrayElementTypes[valueType]; !isArray {
+				if _, err := validateCommandValue(deviceResource, reading, valueType, common.ContentTypeText); err != nil {
+					return fmt.Errorf("%s request data is not valid", method)
+				}
+			}
+		}
+		data := writeBodyData{
+			Value:      reading,
+			Device:     deviceName,
+			Resource:   req.DeviceResourceName,
+			Properties: protocolParams.properties,
+			Attributes: req.Attributes,
+		}
+		body, contentType, err := renderWriteBody(bodyTemplate, data, protocolParams)
+		if err != nil {
+			return fmt.Errorf("%s request data is not valid: %v", method, err)
 		}
 
-		// Its time to form payload to be sent to end device.
-		// For this fisrt get the data received in the write command request
-		// This data is validated against the expected value type of device resource
-		// With the data and uri create new http PUT request
-		// And, set the content type header for the PUT request
-		reading := params[i].Value
-		valueType := deviceResource.Properties.ValueType
+		// Create new request
+		request, err = http.NewRequest(method, uri, strings.NewReader(body))
+		if err != nil {
+			// handle error
+			return fmt.Errorf("%s request creation failed", method)
+		}
+		request.Header.Set(common.ContentType, contentType)
+	} else {
 		switch valueType {
 		case common.ValueTypeObject:
 			buf, _ := json.Marshal(reading)
 			if !json.Valid([]byte(buf)) {
-				return fmt.Errorf("PUT request data is invalid JSON string")
+				return fmt.Errorf("%s request data is invalid JSON string", method)
 			}
 
-			// Create new PUT request, this will not send request to end device
-			request, err = http.NewRequest(http.MethodPut, uri, bytes.NewReader(buf))
+			// Create new request, this will not send request to end device
+			request, err = http.NewRequest(method, uri, bytes.NewReader(buf))
 			if err != nil {
 				// handle error
-				return fmt.Errorf("PUT request creation failed")
+				return fmt.Errorf("%s request creation failed", method)
 			}
 			// Set content type as application/json
 			request.Header.Set(common.ContentType, common.ContentTypeJSON)
@@ -253,49 +480,128 @@ func (driver *RestDriver) HandleWriteCommands(deviceName string, protocols map[s
 			_, err = validateCommandValue(deviceResource, reading, deviceResource.Properties.ValueType, contentType)
 			if err != nil {
 				// handle error
-				return fmt.Errorf("PUT request data is not valid")
+				return fmt.Errorf("%s request data is not valid", method)
 			}
-			// Create new PUT request
-			request, err = http.NewRequest(http.MethodPut, uri, strings.NewReader(cast.ToString(reading)))
+			// Create new request
+			request, err = http.NewRequest(method, uri, strings.NewReader(cast.ToString(reading)))
 			if err != nil {
 				// handle error
-				return fmt.Errorf("PUT request creation failed")
+				return fmt.Errorf("%s request creation failed", method)
 			}
 			// Set content type as text/plain
 			request.Header.Set(common.ContentType, common.ContentTypeText)
 
+		case common.ValueTypeBoolArray, common.ValueTypeStringArray,
+			common.ValueTypeUint8Array, common.ValueTypeUint16Array, common.ValueTypeUint32Array, common.ValueTypeUint64Array,
+			common.ValueTypeInt8Array, common.ValueTypeInt16Array, common.ValueTypeInt32Array, common.ValueTypeInt64Array,
+			common.ValueTypeFloat32Array, common.ValueTypeFloat64Array:
+			// Arrays are sent as JSON array
+			buf, err := marshalArrayValue(reading)
+			if err != nil {
+				return fmt.Errorf("%s request data is not valid: %v", method, err)
+			}
+
+			// Create new request
+			request, err = http.NewRequest(method, uri, bytes.NewReader(buf))
+			if err != nil {
+				// handle error
+				return fmt.Errorf("%s request creation failed", method)
+			}
+			// Set content type as application/json
+			request.Header.Set(common.ContentType, common.ContentTypeJSON)
+
+		case common.ValueTypeBinary:
+			// Binary values are sent as raw bytes of the resource's media type
+			buf, err := binaryWriteBody(reading, req.Attributes)
+			if err != nil {
+				return fmt.Errorf("%s request data is not valid: %v", method, err)
+			}
+			if maxSize := driver.config.AppCustom.MaxBinaryWriteSize; maxSize > 0 && int64(len(buf)) > maxSize {
+				return fmt.Errorf("%s request data of %d bytes exceeds the maximum size of %d bytes", method, len(buf), maxSize)
+			}
+
+			// Create new request
+			request, err = http.NewRequest(method, uri, bytes.NewReader(buf))
+			if err != nil {
+				// handle error
+				return fmt.Errorf("%s request creation failed", method)
+			}
+			// Set content type as the media type of the resource
+			contentType := deviceResource.Properties.MediaType
+			if contentType == "" {
+				contentType = contentTypeOctetStream
+			}
+			request.Header.Set(common.ContentType, contentType)
+
 		default:
 			return fmt.Errorf("unsupported value type: %v", valueType)
 		}
+	}
 
-		// Now we have created http PUT request instance with uri, and payload. This
-		// is enough to initiate PUT request to end device.
-		// First create new http client and initiate PUT request
-		driver.logger.Debugf("Send PUT command to %s", uri)
-		client := &http.Client{}
-		resp, err := client.Do(request)
-		if err != nil {
-			// handle error
-			return fmt.Errorf("PUT request failed to uri = %s", uri)
-		}
+	return driver.sendWriteRequest(deviceName, protocolParams, request, req.Attributes)
+}
 
-		// Htpp status codes till 299 fall under informational/ success category
-		/* 1xx Informational
-		   2xx Success
-		   3xx Redirection
-		   4xx Client Error
-		   5xx Server Error
-		*/
-		// Return immediately if status code is > 299
-		// Ref: https://pkg.go.dev/net/http
-		if resp.StatusCode > 299 {
-			return fmt.Errorf("PUT request failed with status code: %v", resp.StatusCode)
-		}
+// sendWriteRequest sends the write request to the device, authenticated and with the
+// custom headers of the device and the attributes
+func (driver *RestDriver) sendWriteRequest(deviceName string, protocolParams RestProtocolParams, request *http.Request, attributes map[string]any) error {
+	method, uri := request.Method, request.URL.String()
+	if err := driver.setCustomHeaders(request, protocolParams, attributes); err != nil {
+		return fmt.Errorf("%s request headers failed: %v", method, err)
+	}
+	if err := driver.authenticate(deviceName, request, protocolParams); err != nil {
+		return fmt.Errorf("%s request authentication failed: %v", method, err)
+	}
+
+	// Now we have created http request instance with uri, and payload. This
+	// is enough to initiate the request to end device.
+	// First get the http client of the device and initiate the request
+	driver.logger.Debugf("Send %s command to %s", method, uri)
+	client, err := driver.httpClient(deviceName, protocolParams)
+	if err != nil {
+		return fmt.Errorf("http client creation failed: %v", err)
+	}
+	resp, err := driver.sendAuthenticated(deviceName, client, request, protocolParams)
+	if errors.Is(err, errCircuitOpen) {
+		return err
+	}
+	if err != nil {
+		// handle error
+		return fmt.Errorf("%s request failed to uri = %s", method, uri)
+	}
+	// The response body isn't used, drain and close it so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedResponseSize))
+	_ = resp.Body.Close()
+
+	// Htpp status codes till 299 fall under informational/ success category
+	/* 1xx Informational
+	   2xx Success
+	   3xx Redirection
+	   4xx Client Error
+	   5xx Server Error
+	*/
+	// Return immediately if status code is > 299
+	// Ref: https://pkg.go.dev/net/http
+	if resp.StatusCode > 299 {
+		return fmt.Errorf("%s request failed with status code: %v", method, resp.StatusCode)
 	}
 
 	return nil
 }
 
+// marshalArrayValue serializes an array command parameter as JSON array. Byte arrays
+// are sent as array of numbers rather than the base64 string JSON uses for []byte.
+func marshalArrayValue(value interface{}) ([]byte, error) {
+	if bytesValue, ok := value.([]uint8); ok {
+		numbers := make([]uint16, len(bytesValue))
+		for i, b := range bytesValue {
+			numbers[i] = uint16(b)
+		}
+		value = numbers
+	}
+
+	return json.Marshal(value)
+}
+
 // Check for the existence of device parameters in the device file and get them
 func getDeviceParameters(protocols map[string]models.ProtocolProperties) (RestProtocolParams, error) {
 	var restDeviceProtocolParams RestProtocolParams
@@ -305,6 +611,7 @@ func getDeviceParameters(protocols map[string]models.ProtocolProperties) (RestPr
 	}
 
 	var ok bool
+	var err error
 	// Get end device IP address
 	host, ok := protocolParams[RESTHost]
 	if !ok {
@@ -336,16 +643,114 @@ func getDeviceParameters(protocols map[string]models.ProtocolProperties) (RestPr
 		return restDeviceProtocolParams, errors.New("RESTPath is not string type")
 	}
 
+	restDeviceProtocolParams.properties = protocolParams
+
+	// Get the optional scheme and TLS settings of the end device, http is the default
+	restDeviceProtocolParams.scheme = schemeHTTP
+	if scheme, ok := protocolParams[RESTScheme]; ok {
+		restDeviceProtocolParams.scheme = strings.ToLower(fmt.Sprint(scheme))
+		if restDeviceProtocolParams.scheme != schemeHTTP && restDeviceProtocolParams.scheme != schemeHTTPS {
+			return restDeviceProtocolParams, fmt.Errorf("%s must be %s or %s", RESTScheme, schemeHTTP, schemeHTTPS)
+		}
+	}
+	if secretName, ok := protocolParams[TLSSecretName]; ok {
+		restDeviceProtocolParams.tlsSecretName = fmt.Sprint(secretName)
+	}
+	if serverName, ok := protocolParams[TLSServerName]; ok {
+		restDeviceProtocolParams.tlsServerName = fmt.Sprint(serverName)
+	}
+	if insecure, ok := protocolParams[TLSInsecureSkipVerify]; ok {
+		restDeviceProtocolParams.tlsInsecureSkipVerify, err = cast.ToBoolE(insecure)
+		if err != nil {
+			return restDeviceProtocolParams, fmt.Errorf("%s is not bool type", TLSInsecureSkipVerify)
+		}
+	}
+
+	// Get the optional headers of the end device
+	restDeviceProtocolParams.headers, err = parseHeaders(RESTHeaders, protocolParams[RESTHeaders])
+	if err != nil {
+		return restDeviceProtocolParams, err
+	}
+
+	// Get the optional read limit of the end device
+	if property, ok := protocolParams[MaxConcurrentReads]; ok {
+		restDeviceProtocolParams.maxConcurrentReads, err = cast.ToIntE(property)
+		if err != nil || restDeviceProtocolParams.maxConcurrentReads < 1 {
+			return restDeviceProtocolParams, fmt.Errorf("invalid %s: must be a positive number", MaxConcurrentReads)
+		}
+	}
+
+	// Get the optional authentication settings of the end device
+	restDeviceProtocolParams.authMethod, err = parseAuthMethod(protocolParams)
+	if err != nil {
+		return restDeviceProtocolParams, err
+	}
+	if secretName, ok := protocolParams[AuthSecretName]; ok {
+		restDeviceProtocolParams.authSecretName = fmt.Sprint(secretName)
+	}
+	if restDeviceProtocolParams.authMethod != AuthMethodNone && restDeviceProtocolParams.authSecretName == "" {
+		return restDeviceProtocolParams, fmt.Errorf("%s is required with %s %s", AuthSecretName, AuthMethod, restDeviceProtocolParams.authMethod)
+	}
+	switch restDeviceProtocolParams.authMethod {
+	case AuthMethodOAuth2:
+		if err := parseOAuth2Settings(protocolParams, &restDeviceProtocolParams); err != nil {
+			return restDeviceProtocolParams, err
+		}
+	case AuthMethodSession:
+		if err := parseSessionSettings(protocolParams, &restDeviceProtocolParams); err != nil {
+			return restDeviceProtocolParams, err
+		}
+	}
+	restDeviceProtocolParams.apiKeyHeader = apiKeyHeader
+	if header, ok := protocolParams[APIKeyHeader]; ok {
+		restDeviceProtocolParams.apiKeyHeader = fmt.Sprint(header)
+	}
+
+	// Get the optional HTTP client settings of the end device
+	restDeviceProtocolParams.httpClient, err = parseHTTPClientOverrides(protocolParams)
+	if err != nil {
+		return restDeviceProtocolParams, err
+	}
+
+	// Get the optional retry settings of the end device
+	restDeviceProtocolParams.retry, err = parseRetryOverrides(protocolParams)
+	if err != nil {
+		return restDeviceProtocolParams, err
+	}
+
 	return restDeviceProtocolParams, nil
 }
 
+// httpClient returns the shared HTTP client of the device configured with the
+// service's HTTP client configuration, the device's overrides and TLS settings
+func (driver *RestDriver) httpClient(deviceName string, protocolParams RestProtocolParams) (*http.Client, error) {
+	settings := driver.config.AppCustom.HTTPClient.merge(protocolParams.httpClient)
+	settings.tlsServerName = protocolParams.tlsServerName
+	settings.tlsInsecureSkipVerify = protocolParams.tlsInsecureSkipVerify
+
+	material, err := driver.deviceTLSMaterial(protocolParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return driver.clients.client(deviceName, settings, material)
+}
+
+// retryPolicy returns the retry policy of the device, the service's retry
+// configuration with the device's overrides applied
+func (driver *RestDriver) retryPolicy(protocolParams RestProtocolParams) retryPolicy {
+	return driver.config.AppCustom.Retry.merge(protocolParams.retry)
+}
+
 // Stop the protocol-specific DS code to shutdown gracefully, or
 // if the force parameter is 'true', immediately. The driver is responsible
 // for closing any in-use channels, including the channel used to send async
 // readings (if supported).
 func (driver *RestDriver) Stop(force bool) error {
 	driver.logger.Debugf("RestDriver.Stop called: force=%v", force)
-	// Nothing required to do for Stop
+	driver.breakers.close()
+	driver.clients.close()
+	driver.tokens.clients.close()
 	return nil
 }
 
@@ -360,16 +765,29 @@ func (driver *RestDriver) AddDevice(deviceName string, protocols map[string]mode
 // UpdateDevice is a callback function that is invoked
 // when a Device associated with this Device Service is updated
 func (driver *RestDriver) UpdateDevice(deviceName string, protocols map[string]models.ProtocolProperties, adminState models.AdminState) error {
-	// Nothing required to do for UpdateDevice since device update will be available
-	// when data is posted to REST endpoint.
+	// Drop the HTTP client so the next command connects with the updated protocol
+	// properties. Otherwise device update will be available when data is posted to
+	// REST endpoint. A device being probed after an outage is probed with the updated
+	// protocol properties.
+	driver.clients.remove(deviceName)
+	driver.digests.remove(deviceName)
+	driver.sessions.remove(deviceName)
+	if protocolParams, err := getDeviceParameters(protocols); err == nil {
+		driver.breakers.update(deviceName, protocolParams)
+	}
 	return nil
 }
 
 // RemoveDevice is a callback function that is invoked
 // when a Device associated with this Device Service is removed
 func (driver *RestDriver) RemoveDevice(deviceName string, protocols map[string]models.ProtocolProperties) error {
-	// Nothing required to do for RemoveDevice since removed device will no longer be available
-	// when data is posted to REST endpoint.
+	// Close the connections to the removed device. Otherwise removed device will no
+	// longer be available when data is posted to REST endpoint.
+	driver.breakers.remove(deviceName)
+	driver.clients.remove(deviceName)
+	driver.digests.remove(deviceName)
+	driver.sessions.remove(deviceName)
+	driver.reads.remove(deviceName)
 	return nil
 }
 
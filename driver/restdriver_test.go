@@ -0,0 +1,279 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces/mocks"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDriver returns a driver with the given configuration whose device resources
+// are served by the mocked SDK
+func newTestDriver(t *testing.T, config CustomConfig, deviceName string, resources ...models.DeviceResource) (*RestDriver, *mocks.DeviceServiceSDK) {
+	require.NoError(t, config.Validate())
+
+	service := &mocks.DeviceServiceSDK{}
+	service.On("LoggingClient").Return(logger.NewMockClient())
+	for _, resource := range resources {
+		service.On("DeviceResource", deviceName, resource.Name).Return(resource, true)
+	}
+
+	driver := &RestDriver{
+		sdk:      service,
+		logger:   logger.NewMockClient(),
+		config:   &ServiceConfig{AppCustom: config},
+		clients:  newClientPool(),
+		metrics:  newCommandMetrics(),
+		breakers: newCircuitBreakers(),
+		tokens:   newTokenCache(),
+		digests:  newDigestSessions(),
+		sessions: newDeviceSessions(),
+		reads:    newReadLimiters(),
+	}
+
+	return driver, service
+}
+
+// restProtocols returns the REST protocol properties of a device served by the server
+func restProtocols(t *testing.T, server *httptest.Server, extra map[string]any) map[string]models.ProtocolProperties {
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	properties := models.ProtocolProperties{
+		RESTHost: serverURL.Hostname(),
+		RESTPort: serverURL.Port(),
+		RESTPath: "api",
+	}
+	for key, value := range extra {
+		properties[key] = value
+	}
+
+	return map[string]models.ProtocolProperties{RESTProtocol: properties}
+}
+
+func TestHandleReadCommandsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("21.5"))
+	}))
+	defer server.Close()
+
+	resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	driver, _ := newTestDriver(t, CustomConfig{HTTPClient: HTTPClientConfig{Timeout: "5s"}}, "device", resource)
+	reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64}}
+
+	responses, err := driver.HandleReadCommands("device", restProtocols(t, server, nil), reqs)
+	require.NoError(t, err)
+	assert.Equal(t, 21.5, responses[0].Value)
+
+	// The device overrides the service's timeout
+	_, err = driver.HandleReadCommands("device", restProtocols(t, server, map[string]any{HTTPTimeout: "50ms"}), reqs)
+	assert.Error(t, err)
+}
+
+func TestClientPool(t *testing.T) {
+	pool := newClientPool()
+	config := HTTPClientConfig{Timeout: "10s", MaxIdleConns: 2}
+	client := func(deviceName string, overrides HTTPClientConfig) *http.Client {
+		c, err := pool.client(deviceName, config.merge(overrides), tlsMaterial{})
+		require.NoError(t, err)
+		return c
+	}
+
+	first := client("device1", HTTPClientConfig{})
+	assert.Same(t, first, client("device1", HTTPClientConfig{}), "client must be reused")
+	assert.NotSame(t, first, client("device2", HTTPClientConfig{}), "clients are per device")
+	assert.Equal(t, 10*time.Second, first.Timeout)
+
+	updated := client("device1", HTTPClientConfig{Timeout: "1s"})
+	assert.NotSame(t, first, updated, "client must be replaced when the settings change")
+	assert.Equal(t, time.Second, updated.Timeout)
+
+	pool.remove("device1")
+	assert.NotSame(t, updated, client("device1", HTTPClientConfig{Timeout: "1s"}))
+}
+
+func TestGetDeviceParametersHTTPClient(t *testing.T) {
+	protocols := map[string]models.ProtocolProperties{
+		RESTProtocol: {
+			RESTHost:         "localhost",
+			RESTPort:         "5000",
+			RESTPath:         "",
+			HTTPTimeout:      "3s",
+			HTTPMaxIdleConns: "4",
+		},
+	}
+	params, err := getDeviceParameters(protocols)
+	require.NoError(t, err)
+	assert.Equal(t, HTTPClientConfig{Timeout: "3s", MaxIdleConns: 4}, params.httpClient)
+
+	protocols[RESTProtocol][HTTPReadTimeout] = "soon"
+	_, err = getDeviceParameters(protocols)
+	assert.Error(t, err)
+
+	// Zero would silently keep the service's setting, so it's rejected like negative values
+	delete(protocols[RESTProtocol], HTTPReadTimeout)
+	for _, maxIdleConns := range []string{"0", "-1"} {
+		protocols[RESTProtocol][HTTPMaxIdleConns] = maxIdleConns
+		_, err = getDeviceParameters(protocols)
+		assert.ErrorContains(t, err, "must be a positive number", maxIdleConns)
+	}
+}
+
+func TestHandleWriteCommandsBinary(t *testing.T) {
+	var contentType string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get(common.ContentType)
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	image := models.DeviceResource{Name: "image", Properties: models.ResourceProperties{ValueType: common.ValueTypeBinary, MediaType: "image/png"}}
+	firmware := models.DeviceResource{Name: "firmware", Properties: models.ResourceProperties{ValueType: common.ValueTypeBinary}}
+	driver, _ := newTestDriver(t, CustomConfig{MaxBinaryWriteSize: 8}, "device", image, firmware)
+	protocols := restProtocols(t, server, nil)
+
+	tests := []struct {
+		name                string
+		resource            models.DeviceResource
+		value               any
+		attributes          map[string]any
+		expectedContentType string
+		expectedBody        []byte
+		expectedError       bool
+	}{
+		{"bytes", image, []byte{0x89, 'P', 'N', 'G'}, nil, "image/png", []byte{0x89, 'P', 'N', 'G'}, false},
+		{"without media type", firmware, []byte{1, 2}, nil, contentTypeOctetStream, []byte{1, 2}, false},
+		{"string", firmware, "raw", nil, contentTypeOctetStream, []byte("raw"), false},
+		{"base64 string", firmware, "AQID", map[string]any{Base64Decode: true}, contentTypeOctetStream, []byte{1, 2, 3}, false},
+		{"invalid base64", firmware, "AQI!", map[string]any{Base64Decode: "true"}, "", nil, true},
+		{"too large", firmware, make([]byte, 9), nil, "", nil, true},
+		{"too large decoded", firmware, "AAAAAAAAAAAA", map[string]any{Base64Decode: true}, "", nil, true},
+		{"unsupported value", firmware, 42, nil, "", nil, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			contentType, body = "", nil
+			reqs := []sdkModels.CommandRequest{{DeviceResourceName: testCase.resource.Name, Type: common.ValueTypeBinary, Attributes: testCase.attributes}}
+			params := []*sdkModels.CommandValue{{DeviceResourceName: testCase.resource.Name, Type: common.ValueTypeBinary, Value: testCase.value}}
+
+			err := driver.HandleWriteCommands("device", protocols, reqs, params)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, body, "request must not be sent")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedContentType, contentType)
+			assert.Equal(t, testCase.expectedBody, body)
+		})
+	}
+}
+
+func TestHandleReadCommandsConcurrent(t *testing.T) {
+	// Requests are held in groups of barrier until all of a group arrived, so that
+	// reads have to be concurrent to complete and the limit is reached
+	var mutex sync.Mutex
+	var inFlight, maxInFlight, arrived, barrier int
+	var timedOut atomic.Bool
+	groups := map[int]chan struct{}{}
+	reset := func(size int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		maxInFlight, arrived, barrier = 0, 0, size
+		clear(groups)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		group := arrived / barrier
+		if groups[group] == nil {
+			groups[group] = make(chan struct{})
+		}
+		arrived++
+		if arrived%barrier == 0 {
+			close(groups[group])
+		}
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		release := groups[group]
+		mutex.Unlock()
+
+		select {
+		case <-release:
+		case <-time.After(5 * time.Second):
+			timedOut.Store(true)
+		}
+		mutex.Lock()
+		inFlight--
+		mutex.Unlock()
+
+		if r.URL.Path == "/api/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(strings.TrimPrefix(r.URL.Path, "/api/r")))
+	}))
+	defer server.Close()
+
+	var resources []models.DeviceResource
+	var reqs []sdkModels.CommandRequest
+	for i := range 6 {
+		resource := models.DeviceResource{Name: fmt.Sprintf("r%d", i), Properties: models.ResourceProperties{ValueType: common.ValueTypeInt32}}
+		resources = append(resources, resource)
+		reqs = append(reqs, sdkModels.CommandRequest{DeviceResourceName: resource.Name, Type: common.ValueTypeInt32})
+	}
+	broken := models.DeviceResource{Name: "broken", Properties: models.ResourceProperties{ValueType: common.ValueTypeInt32}}
+	driver, _ := newTestDriver(t, CustomConfig{MaxConcurrentReads: 2}, "device", append(resources, broken)...)
+
+	reset(2)
+	responses, err := driver.HandleReadCommands("device", restProtocols(t, server, nil), reqs)
+	require.NoError(t, err)
+	require.Len(t, responses, len(reqs))
+	for i, response := range responses {
+		assert.Equal(t, int32(i), response.Value, "responses must be in the order of the requests")
+	}
+	assert.Equal(t, 2, maxInFlight, "reads must be limited to MaxConcurrentReads")
+	assert.False(t, timedOut.Load(), "reads must be concurrent")
+
+	// The device's protocol property overrides the service's limit
+	reset(6)
+	_, err = driver.HandleReadCommands("device", restProtocols(t, server, map[string]any{MaxConcurrentReads: "6"}), reqs)
+	require.NoError(t, err)
+	assert.Equal(t, 6, maxInFlight)
+	assert.False(t, timedOut.Load(), "reads must be concurrent")
+
+	// Resources read successfully are returned along with the errors of the others
+	reset(1)
+	partial := []sdkModels.CommandRequest{reqs[0], {DeviceResourceName: broken.Name, Type: common.ValueTypeInt32}, reqs[2]}
+	responses, err = driver.HandleReadCommands("device", restProtocols(t, server, nil), partial)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken: ")
+	require.Len(t, responses, 3)
+	assert.Equal(t, int32(0), responses[0].Value)
+	assert.Nil(t, responses[1])
+	assert.Equal(t, int32(2), responses[2].Value)
+
+	_, err = getDeviceParameters(restProtocols(t, server, map[string]any{MaxConcurrentReads: "0"}))
+	assert.Error(t, err)
+}
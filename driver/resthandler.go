@@ -18,12 +18,19 @@
 package driver
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"mime"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces"
@@ -38,32 +45,102 @@ import (
 
 const (
 	apiResourceRoute  = common.ApiBase + "/resource/:deviceName/:resourceName"
+	apiDeviceRoute    = common.ApiBase + "/resource/:deviceName"
+	apiBulkRoute      = common.ApiBase + "/resource"
 	handlerContextKey = "RestHandler"
+
+	// ContentTypeNDJSON is the content type of newline-delimited JSON bulk requests
+	ContentTypeNDJSON = "application/x-ndjson"
+	// maxBulkLineSize limits the size of a single record of a bulk request
+	maxBulkLineSize = 4 * 1024 * 1024
 )
 
+// BatchResponse reports which resources of a multi-resource POST were accepted
+// and, for the rejected ones, why they were rejected.
+type BatchResponse struct {
+	DeviceName string            `json:"deviceName"`
+	Accepted   []string          `json:"accepted"`
+	Rejected   map[string]string `json:"rejected,omitempty"`
+}
+
 type RestHandler struct {
 	service     interfaces.DeviceServiceSDK
 	logger      logger.LoggingClient
 	asyncValues chan<- *models.AsyncValues
+	config      CustomConfig
+	provisioner *deviceProvisioner
+	metrics     *asyncValuesMetrics
+	nonces      *nonceCache
 }
 
-func NewRestHandler(sdk interfaces.DeviceServiceSDK) *RestHandler {
+func NewRestHandler(sdk interfaces.DeviceServiceSDK, config CustomConfig) *RestHandler {
 	handler := RestHandler{
 		service:     sdk,
 		logger:      sdk.LoggingClient(),
 		asyncValues: sdk.AsyncValuesChannel(),
+		config:      config,
+		provisioner: newDeviceProvisioner(sdk, config.AutoProvision),
+		metrics:     newAsyncValuesMetrics(),
+		nonces:      newNonceCache(),
 	}
 
 	return &handler
 }
 
+// BulkRecord is a single line of a newline-delimited JSON bulk request
+type BulkRecord struct {
+	Device   string          `json:"device"`
+	Resource string          `json:"resource"`
+	Value    json.RawMessage `json:"value"`
+	// Origin is the optional reading timestamp, either RFC3339 or epoch seconds,
+	// milliseconds or nanoseconds
+	Origin json.RawMessage `json:"origin,omitempty"`
+}
+
+// BulkLineResult reports the outcome of a single line of a bulk request
+type BulkLineResult struct {
+	Line         int    `json:"line"`
+	DeviceName   string `json:"deviceName,omitempty"`
+	ResourceName string `json:"resourceName,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// BulkResponse summarizes the outcome of a bulk request
+type BulkResponse struct {
+	Accepted int              `json:"accepted"`
+	Rejected int              `json:"rejected"`
+	Results  []BulkLineResult `json:"results"`
+}
+
 func (handler RestHandler) Start() error {
-	if err := handler.service.AddCustomRoute(apiResourceRoute, interfaces.Authenticated, handler.addContext(deviceHandler), http.MethodPost); err != nil {
+	if err := handler.metrics.register(handler); err != nil {
+		handler.logger.Warnf("Readings metrics not available: %s", err.Error())
+	}
+
+	// Devices authenticating with their own credentials can't obtain an EdgeX issued JWT
+	authentication := interfaces.Authenticated
+	if handler.config.IngestionAuth == IngestionAuthDeviceKey || handler.config.IngestionAuth == IngestionAuthHMAC {
+		authentication = interfaces.Unauthenticated
+	}
+
+	if err := handler.service.AddCustomRoute(apiResourceRoute, authentication, handler.addContext(deviceHandler), http.MethodPost); err != nil {
 		return fmt.Errorf("unable to add required route: %s: %s", apiResourceRoute, err.Error())
 	}
 
 	handler.logger.Infof("Route %s added.", apiResourceRoute)
 
+	if err := handler.service.AddCustomRoute(apiDeviceRoute, authentication, handler.addContext(batchHandler), http.MethodPost); err != nil {
+		return fmt.Errorf("unable to add required route: %s: %s", apiDeviceRoute, err.Error())
+	}
+
+	handler.logger.Infof("Route %s added.", apiDeviceRoute)
+
+	if err := handler.service.AddCustomRoute(apiBulkRoute, authentication, handler.addContext(bulkHandler), http.MethodPost); err != nil {
+		return fmt.Errorf("unable to add required route: %s: %s", apiBulkRoute, err.Error())
+	}
+
+	handler.logger.Infof("Route %s added.", apiBulkRoute)
+
 	return nil
 }
 
@@ -84,8 +161,14 @@ func (handler RestHandler) processAsyncRequest(c echo.Context) error {
 
 	_, err := handler.service.GetDeviceByName(deviceName)
 	if err != nil {
-		handler.logger.Errorf("Incoming reading ignored. Device '%s' not found", deviceName)
-		return c.String(http.StatusNotFound, fmt.Sprintf("Device '%s' not found", deviceName))
+		if handler.provisioner == nil {
+			handler.logger.Errorf("Incoming reading ignored. Device '%s' not found", deviceName)
+			return c.String(http.StatusNotFound, fmt.Sprintf("Device '%s' not found", deviceName))
+		}
+		if status, err := handler.provisionDevice(deviceName, c.Request()); err != nil {
+			handler.logger.Errorf("Incoming reading ignored. %s", err.Error())
+			return c.String(status, err.Error())
+		}
 	}
 
 	deviceResource, ok := handler.service.DeviceResource(deviceName, resourceName)
@@ -94,6 +177,12 @@ func (handler RestHandler) processAsyncRequest(c echo.Context) error {
 		return c.String(http.StatusNotFound, fmt.Sprintf("Resource '%s' not found", resourceName))
 	}
 
+	origin, err := handler.config.requestOrigin(c.Request())
+	if err != nil {
+		handler.logger.Errorf("Incoming reading ignored. Invalid origin: %s", err.Error())
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
 	contentType := c.Request().Header.Get(common.ContentType)
 
 	var reading interface{}
@@ -110,20 +199,15 @@ func (handler RestHandler) processAsyncRequest(c echo.Context) error {
 		reading = string(data)
 	}
 
-	value, err := validateCommandValue(deviceResource, reading, deviceResource.Properties.ValueType, contentType)
-	if err != nil {
-		handler.logger.Errorf("Incoming reading ignored. Unable to validate Command Value for Device=%s Command=%s: %s",
-			deviceName, resourceName, err.Error())
-		return c.String(http.StatusBadRequest, err.Error())
-	}
-
-	result, err := models.NewCommandValue(deviceResource.Name, deviceResource.Properties.ValueType, value)
+	result, err := newCommandValue(deviceResource, reading, contentType)
 	if err != nil {
 		handler.logger.Errorf("Incoming reading ignored. Unable to create Command Value for Device=%s Command=%s: %s",
 			deviceName, resourceName, err.Error())
 		return c.String(http.StatusBadRequest, err.Error())
 	}
-	result.Origin = time.Now().UnixNano()
+	if origin > 0 {
+		result.Origin = origin
+	}
 
 	asyncValues := &models.AsyncValues{
 		DeviceName:    deviceName,
@@ -132,11 +216,304 @@ func (handler RestHandler) processAsyncRequest(c echo.Context) error {
 
 	handler.logger.Debugf("Incoming reading received: Device=%s Resource=%s", deviceName, resourceName)
 
-	handler.asyncValues <- asyncValues
+	if err := handler.sendAsyncValues(c.Request().Context(), asyncValues); err != nil {
+		handler.logger.Errorf("Incoming reading dropped: Device=%s Resource=%s: %s", deviceName, resourceName, err.Error())
+		return handler.overloaded(c, nil)
+	}
 
 	return nil
 }
 
+func (handler RestHandler) processBatchRequest(c echo.Context) error {
+	deviceName := c.Param(common.DeviceName)
+
+	handler.logger.Debugf("Received batch POST for Device=%s", deviceName)
+
+	_, err := handler.service.GetDeviceByName(deviceName)
+	if err != nil {
+		if handler.provisioner == nil {
+			handler.logger.Errorf("Incoming readings ignored. Device '%s' not found", deviceName)
+			return c.String(http.StatusNotFound, fmt.Sprintf("Device '%s' not found", deviceName))
+		}
+		if status, err := handler.provisionDevice(deviceName, c.Request()); err != nil {
+			handler.logger.Errorf("Incoming readings ignored. %s", err.Error())
+			return c.String(status, err.Error())
+		}
+	}
+
+	origin, err := handler.config.requestOrigin(c.Request())
+	if err != nil {
+		handler.logger.Errorf("Incoming readings ignored. Invalid origin: %s", err.Error())
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	contentType := c.Request().Header.Get(common.ContentType)
+	if !hasMediaType(contentType, common.ContentTypeJSON) {
+		handler.logger.Errorf("Incoming readings ignored. Wrong Content-Type '%s'", contentType)
+		return c.String(http.StatusBadRequest, fmt.Sprintf("wrong Content-Type: expected '%s' but received '%s'", common.ContentTypeJSON, contentType))
+	}
+
+	data, err := handler.readBody(c.Request())
+	if err != nil {
+		handler.logger.Errorf("Incoming readings ignored. Unable to read request body: %s", err.Error())
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		handler.logger.Errorf("Incoming readings ignored. Unable to unmarshal request body: %s", err.Error())
+		return c.String(http.StatusBadRequest, fmt.Sprintf("request body must be a JSON object keyed by resource name: %s", err.Error()))
+	}
+	if len(values) == 0 {
+		return c.String(http.StatusBadRequest, "no resource values provided")
+	}
+
+	// Process the resources in a stable order so the CommandValues of the event
+	// and the response are deterministic
+	resourceNames := make([]string, 0, len(values))
+	for resourceName := range values {
+		resourceNames = append(resourceNames, resourceName)
+	}
+	sort.Strings(resourceNames)
+
+	response := BatchResponse{
+		DeviceName: deviceName,
+		Accepted:   []string{},
+		Rejected:   map[string]string{},
+	}
+	commandValues := make([]*models.CommandValue, 0, len(values))
+	for _, resourceName := range resourceNames {
+		deviceResource, ok := handler.service.DeviceResource(deviceName, resourceName)
+		if !ok {
+			response.Rejected[resourceName] = fmt.Sprintf("Resource '%s' not found", resourceName)
+			continue
+		}
+
+		result, err := newCommandValueFromJSON(deviceResource, values[resourceName])
+		if err != nil {
+			response.Rejected[resourceName] = err.Error()
+			continue
+		}
+		if origin > 0 {
+			result.Origin = origin
+		}
+
+		commandValues = append(commandValues, result)
+		response.Accepted = append(response.Accepted, resourceName)
+	}
+
+	for resourceName, reason := range response.Rejected {
+		handler.logger.Errorf("Incoming reading ignored. Device=%s Resource=%s: %s", deviceName, resourceName, reason)
+	}
+
+	if len(commandValues) == 0 {
+		return c.JSON(http.StatusBadRequest, response)
+	}
+
+	asyncValues := &models.AsyncValues{
+		DeviceName:    deviceName,
+		CommandValues: commandValues,
+	}
+
+	handler.logger.Debugf("Incoming readings received: Device=%s Resources=%v", deviceName, response.Accepted)
+
+	if err := handler.sendAsyncValues(c.Request().Context(), asyncValues); err != nil {
+		handler.logger.Errorf("Incoming readings dropped: Device=%s: %s", deviceName, err.Error())
+		return handler.overloaded(c, nil)
+	}
+
+	if len(response.Rejected) > 0 {
+		return c.JSON(http.StatusMultiStatus, response)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// hasMediaType checks the media type of the content type, ignoring parameters like charset
+func hasMediaType(contentType string, expected string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == expected
+}
+
+func (handler RestHandler) processBulkRequest(c echo.Context) error {
+	handler.logger.Debug("Received bulk POST")
+
+	contentType := c.Request().Header.Get(common.ContentType)
+	if !hasMediaType(contentType, ContentTypeNDJSON) {
+		handler.logger.Errorf("Incoming readings ignored. Wrong Content-Type '%s'", contentType)
+		return c.String(http.StatusBadRequest, fmt.Sprintf("wrong Content-Type: expected '%s' but received '%s'", ContentTypeNDJSON, contentType))
+	}
+
+	defer c.Request().Body.Close()
+
+	// The origin supplied with the request applies to all records without an origin
+	origin, err := handler.config.requestOrigin(c.Request())
+	if err != nil {
+		handler.logger.Errorf("Incoming readings ignored. Invalid origin: %s", err.Error())
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	response := BulkResponse{Results: []BulkLineResult{}}
+	// Readings are grouped per device, keeping the devices in order of appearance
+	var deviceOrder []string
+	deviceValues := map[string][]*models.CommandValue{}
+	// Index of the results of each device's readings, in case they must be dropped
+	deviceResults := map[string][]int{}
+	knownDevices := map[string]bool{}
+
+	scanner := bufio.NewScanner(c.Request().Body)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxBulkLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		result := BulkLineResult{Line: line}
+		value, err := handler.bulkRecordValue(c.Request(), data, origin, knownDevices, &result)
+		if err != nil {
+			handler.logger.Errorf("Incoming reading on line %d ignored: %s", line, err.Error())
+			result.Error = err.Error()
+			response.Rejected++
+			response.Results = append(response.Results, result)
+			continue
+		}
+
+		if _, ok := deviceValues[result.DeviceName]; !ok {
+			deviceOrder = append(deviceOrder, result.DeviceName)
+		}
+		deviceValues[result.DeviceName] = append(deviceValues[result.DeviceName], value)
+		deviceResults[result.DeviceName] = append(deviceResults[result.DeviceName], len(response.Results))
+		response.Accepted++
+		response.Results = append(response.Results, result)
+	}
+	if err := scanner.Err(); err != nil {
+		handler.logger.Errorf("Incoming readings ignored. Unable to read request body after line %d: %s", line, err.Error())
+		return c.String(http.StatusBadRequest, fmt.Sprintf("unable to read request body after line %d: %s", line, err.Error()))
+	}
+
+	if line == 0 {
+		return c.String(http.StatusBadRequest, "no request body provided")
+	}
+
+	// Once the SDK doesn't accept readings in time, the readings of the remaining
+	// devices are dropped as well rather than waiting for each of them
+	var sendErr error
+	for _, deviceName := range deviceOrder {
+		if sendErr == nil {
+			handler.logger.Debugf("Incoming readings received: Device=%s Count=%d", deviceName, len(deviceValues[deviceName]))
+			sendErr = handler.sendAsyncValues(c.Request().Context(), &models.AsyncValues{
+				DeviceName:    deviceName,
+				CommandValues: deviceValues[deviceName],
+			})
+			if sendErr == nil {
+				continue
+			}
+		} else {
+			handler.metrics.readingsDropped.Inc(int64(len(deviceValues[deviceName])))
+		}
+
+		handler.logger.Errorf("Incoming readings dropped: Device=%s: %s", deviceName, sendErr.Error())
+		for _, index := range deviceResults[deviceName] {
+			response.Results[index].Error = errAsyncValuesTimeout.Error()
+			response.Accepted--
+			response.Rejected++
+		}
+	}
+	if sendErr != nil {
+		return handler.overloaded(c, response)
+	}
+
+	switch {
+	case response.Accepted == 0:
+		return c.JSON(http.StatusBadRequest, response)
+	case response.Rejected > 0:
+		return c.JSON(http.StatusMultiStatus, response)
+	default:
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// bulkRecordValue parses a single bulk record and creates its CommandValue. The
+// device and resource names are recorded in the result as soon as they are known.
+// defaultOrigin is used for records without an origin, knownDevices caches the
+// device lookups of the current request.
+func (handler RestHandler) bulkRecordValue(request *http.Request, data []byte, defaultOrigin int64, knownDevices map[string]bool, result *BulkLineResult) (*models.CommandValue, error) {
+	var record BulkRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("invalid JSON record: %s", err.Error())
+	}
+	result.DeviceName = record.Device
+	result.ResourceName = record.Resource
+
+	if record.Device == "" || record.Resource == "" {
+		return nil, errors.New("record must contain device and resource")
+	}
+
+	// The credentials of the request must be the ones of each record's device
+	if err := handler.authorizeDevice(record.Device, request); err != nil {
+		return nil, err
+	}
+
+	found, ok := knownDevices[record.Device]
+	if !ok {
+		_, err := handler.service.GetDeviceByName(record.Device)
+		found = err == nil
+		if !found && handler.provisioner != nil {
+			_, err = handler.provisionDevice(record.Device, request)
+			found = err == nil
+		}
+		knownDevices[record.Device] = found
+	}
+	if !found {
+		return nil, fmt.Errorf("device '%s' not found", record.Device)
+	}
+
+	deviceResource, ok := handler.service.DeviceResource(record.Device, record.Resource)
+	if !ok {
+		return nil, fmt.Errorf("resource '%s' not found", record.Resource)
+	}
+
+	origin := defaultOrigin
+	if len(record.Origin) > 0 && string(record.Origin) != "null" {
+		recordOrigin, err := parseJSONOrigin(record.Origin)
+		if err != nil {
+			return nil, err
+		}
+		if err := handler.config.checkOrigin(recordOrigin, time.Now()); err != nil {
+			return nil, err
+		}
+		origin = recordOrigin
+	}
+
+	value, err := newCommandValueFromJSON(deviceResource, record.Value)
+	if err != nil {
+		return nil, err
+	}
+	if origin > 0 {
+		value.Origin = origin
+	}
+
+	return value, nil
+}
+
+// provisionDevice creates the unknown device if auto provisioning allows it. On
+// failure the HTTP status code to respond with is returned along with the error.
+func (handler RestHandler) provisionDevice(deviceName string, request *http.Request) (int, error) {
+	if err := handler.provisioner.provision(deviceName, request); err != nil {
+		if errors.Is(err, errProvisioningNotAllowed) {
+			return http.StatusNotFound, fmt.Errorf("device '%s' not found and not auto provisioned: %s", deviceName, err.Error())
+		}
+		return http.StatusInternalServerError, fmt.Errorf("device '%s' not found and auto provisioning failed: %s", deviceName, err.Error())
+	}
+
+	handler.logger.Infof("Device '%s' auto provisioned", deviceName)
+
+	return http.StatusOK, nil
+}
+
 func (handler RestHandler) readBody(request *http.Request) ([]byte, error) {
 	defer request.Body.Close()
 	body, err := io.ReadAll(request.Body)
@@ -157,13 +534,97 @@ func deviceHandler(c echo.Context) error {
 		return c.String(http.StatusBadRequest, "Bad context pass to handler")
 	}
 
+	if err := handler.authorizeDevice(c.Param(common.DeviceName), c.Request()); err != nil {
+		return handler.unauthorized(c, err)
+	}
+
 	return handler.processAsyncRequest(c)
 }
 
+func bulkHandler(c echo.Context) error {
+	handler, ok := c.Request().Context().Value(handlerContextKey).(RestHandler)
+	if !ok {
+		return c.String(http.StatusBadRequest, "Bad context pass to handler")
+	}
+
+	// A signature covers the whole body, so it can't authorize readings of several devices
+	if handler.config.IngestionAuth == IngestionAuthHMAC {
+		return handler.unauthorized(c, nil)
+	}
+
+	return handler.processBulkRequest(c)
+}
+
+func batchHandler(c echo.Context) error {
+	handler, ok := c.Request().Context().Value(handlerContextKey).(RestHandler)
+	if !ok {
+		return c.String(http.StatusBadRequest, "Bad context pass to handler")
+	}
+
+	if err := handler.authorizeDevice(c.Param(common.DeviceName), c.Request()); err != nil {
+		return handler.unauthorized(c, err)
+	}
+
+	return handler.processBatchRequest(c)
+}
+
+// newCommandValue validates the reading against the device resource and
+// creates the CommandValue to be sent to the SDK
+func newCommandValue(resource model.DeviceResource, reading interface{}, contentType string) (*models.CommandValue, error) {
+	value, err := validateCommandValue(resource, reading, resource.Properties.ValueType, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := models.NewCommandValue(resource.Name, resource.Properties.ValueType, value)
+	if err != nil {
+		return nil, err
+	}
+	result.Origin = time.Now().UnixNano()
+
+	return result, nil
+}
+
+// newCommandValueFromJSON creates the CommandValue from a single JSON value of a
+// multi-resource request. Objects are passed on as JSON, strings are unquoted and
+// all other JSON values are taken as their text representation.
+func newCommandValueFromJSON(resource model.DeviceResource, raw json.RawMessage) (*models.CommandValue, error) {
+	var reading interface{}
+	contentType := common.ContentTypeText
+
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, errors.New("no value provided")
+	}
+
+	switch resource.Properties.ValueType {
+	case common.ValueTypeBinary:
+		return nil, fmt.Errorf("value type %s is not supported in multi-resource requests", common.ValueTypeBinary)
+	case common.ValueTypeObject:
+		reading = []byte(raw)
+		contentType = common.ContentTypeJSON
+	default:
+		var str string
+		if err := json.Unmarshal(raw, &str); err == nil {
+			reading = str
+		} else {
+			reading = string(raw)
+		}
+	}
+
+	return newCommandValue(resource, reading, contentType)
+}
+
 func validateCommandValue(resource model.DeviceResource, reading interface{}, valueType string, contentType string) (interface{}, error) {
 	var err error
 	castError := "failed to parse %v reading, %v"
 
+	// Casting text to integer types silently wraps around, so check the range first
+	if text, ok := reading.(string); ok {
+		if err := checkTextValueRange(valueType, text); err != nil {
+			return nil, err
+		}
+	}
+
 	var val interface{}
 	switch valueType {
 	case common.ValueTypeBinary:
@@ -279,6 +740,14 @@ func validateCommandValue(resource model.DeviceResource, reading interface{}, va
 		if err := checkFloatValueRange(valueType, val); err != nil {
 			return nil, err
 		}
+	case common.ValueTypeBoolArray, common.ValueTypeStringArray,
+		common.ValueTypeUint8Array, common.ValueTypeUint16Array, common.ValueTypeUint32Array, common.ValueTypeUint64Array,
+		common.ValueTypeInt8Array, common.ValueTypeInt16Array, common.ValueTypeInt32Array, common.ValueTypeInt64Array,
+		common.ValueTypeFloat32Array, common.ValueTypeFloat64Array:
+		val, err = validateArrayValue(resource, reading, valueType, contentType)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("return result fail, unsupported value type: %v", valueType)
 	}
@@ -286,6 +755,154 @@ func validateCommandValue(resource model.DeviceResource, reading interface{}, va
 	return val, nil
 }
 
+// arrayElementTypes maps the array value types to the value type of their elements
+var arrayElementTypes = map[string]string{
+	common.ValueTypeBoolArray:    common.ValueTypeBool,
+	common.ValueTypeStringArray:  common.ValueTypeString,
+	common.ValueTypeUint8Array:   common.ValueTypeUint8,
+	common.ValueTypeUint16Array:  common.ValueTypeUint16,
+	common.ValueTypeUint32Array:  common.ValueTypeUint32,
+	common.ValueTypeUint64Array:  common.ValueTypeUint64,
+	common.ValueTypeInt8Array:    common.ValueTypeInt8,
+	common.ValueTypeInt16Array:   common.ValueTypeInt16,
+	common.ValueTypeInt32Array:   common.ValueTypeInt32,
+	common.ValueTypeInt64Array:   common.ValueTypeInt64,
+	common.ValueTypeFloat32Array: common.ValueTypeFloat32,
+	common.ValueTypeFloat64Array: common.ValueTypeFloat64,
+}
+
+// validateArrayValue parses an array reading given either as JSON array or as comma
+// separated text and validates each element against the element value type
+func validateArrayValue(resource model.DeviceResource, reading interface{}, valueType string, contentType string) (interface{}, error) {
+	text, err := cast.ToStringE(reading)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %v reading, %v", resource.Name, err)
+	}
+
+	elements, err := splitArrayValue(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %v reading, %v", resource.Name, err)
+	}
+
+	elementType := arrayElementTypes[valueType]
+	values := make([]interface{}, len(elements))
+	for i, element := range elements {
+		values[i], err = validateCommandValue(resource, element, elementType, contentType)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %v", i, err)
+		}
+	}
+
+	switch valueType {
+	case common.ValueTypeBoolArray:
+		return toTypedSlice[bool](values), nil
+	case common.ValueTypeStringArray:
+		return toTypedSlice[string](values), nil
+	case common.ValueTypeUint8Array:
+		return toTypedSlice[uint8](values), nil
+	case common.ValueTypeUint16Array:
+		return toTypedSlice[uint16](values), nil
+	case common.ValueTypeUint32Array:
+		return toTypedSlice[uint32](values), nil
+	case common.ValueTypeUint64Array:
+		return toTypedSlice[uint64](values), nil
+	case common.ValueTypeInt8Array:
+		return toTypedSlice[int8](values), nil
+	case common.ValueTypeInt16Array:
+		return toTypedSlice[int16](values), nil
+	case common.ValueTypeInt32Array:
+		return toTypedSlice[int32](values), nil
+	case common.ValueTypeInt64Array:
+		return toTypedSlice[int64](values), nil
+	case common.ValueTypeFloat32Array:
+		return toTypedSlice[float32](values), nil
+	default:
+		return toTypedSlice[float64](values), nil
+	}
+}
+
+// splitArrayValue splits a JSON array or comma separated text into the text of its
+// elements. Empty text is an empty array.
+func splitArrayValue(text string) ([]string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return []string{}, nil
+	}
+
+	if !strings.HasPrefix(text, "[") {
+		elements := strings.Split(text, ",")
+		for i := range elements {
+			elements[i] = strings.TrimSpace(elements[i])
+		}
+		return elements, nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var array []interface{}
+	if err := decoder.Decode(&array); err != nil {
+		return nil, fmt.Errorf("invalid JSON array: %v", err)
+	}
+
+	elements := make([]string, len(array))
+	for i, element := range array {
+		switch value := element.(type) {
+		case string:
+			elements[i] = value
+		case json.Number:
+			elements[i] = value.String()
+		case bool:
+			elements[i] = strconv.FormatBool(value)
+		default:
+			return nil, fmt.Errorf("element %d is not a string, number or bool", i)
+		}
+	}
+
+	return elements, nil
+}
+
+// toTypedSlice converts the validated elements into a slice of their type
+func toTypedSlice[T any](values []interface{}) []T {
+	result := make([]T, len(values))
+	for i, value := range values {
+		result[i] = value.(T)
+	}
+
+	return result
+}
+
+// integerRanges holds the minimum and maximum of the integer value types
+var integerRanges = map[string][2]float64{
+	common.ValueTypeUint8:  {0, math.MaxUint8},
+	common.ValueTypeUint16: {0, math.MaxUint16},
+	common.ValueTypeUint32: {0, math.MaxUint32},
+	common.ValueTypeUint64: {0, math.MaxUint64},
+	common.ValueTypeInt8:   {math.MinInt8, math.MaxInt8},
+	common.ValueTypeInt16:  {math.MinInt16, math.MaxInt16},
+	common.ValueTypeInt32:  {math.MinInt32, math.MaxInt32},
+	common.ValueTypeInt64:  {math.MinInt64, math.MaxInt64},
+}
+
+// checkTextValueRange checks that a numeric text is within the range of the integer
+// value type. Text that isn't numeric is left to the type conversion to reject.
+func checkTextValueRange(valueType string, text string) error {
+	limits, ok := integerRanges[valueType]
+	if !ok {
+		return nil
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return nil
+	}
+
+	if math.Trunc(number) < limits[0] || math.Trunc(number) > limits[1] {
+		return fmt.Errorf("value %v for %s type is out of range", text, valueType)
+	}
+
+	return nil
+}
+
 func checkUintValueRange(valueType string, val interface{}) error {
 	switch valueType {
 	case common.ValueTypeUint8:
@@ -19,7 +19,11 @@ package driver
 
 import (
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces/mocks"
@@ -28,7 +32,9 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,7 +46,7 @@ func TestMain(m *testing.M) {
 	service.On("LoggingClient").Return(logger.NewMockClient())
 	service.On("AsyncValuesChannel").Return(asyncValues)
 
-	handler = NewRestHandler(service)
+	handler = NewRestHandler(service, CustomConfig{})
 	os.Exit(m.Run())
 }
 
@@ -112,6 +118,27 @@ func TestNewCommandValue(t *testing.T) {
 		{"Test A Object", []byte(objectJson), expectedObject, common.ValueTypeObject, "", "application/json", false},
 		{"Test A Object error", "---", nil, common.ValueTypeObject, "", "application/json", true},
 		{"Test A Object error", "....", nil, common.ValueTypeObject, "", "text/plain", true},
+		{"Test A Uint8 out of range", "256", nil, common.ValueTypeUint8, "", "text/plain", true},
+		{"Test A Int8 out of range", "-129", nil, common.ValueTypeInt8, "", "text/plain", true},
+		{"Test A BoolArray JSON", "[true, false]", []bool{true, false}, common.ValueTypeBoolArray, "", "application/json", false},
+		{"Test A StringArray JSON", `["a,b", "c"]`, []string{"a,b", "c"}, common.ValueTypeStringArray, "", "application/json", false},
+		{"Test A StringArray text", "a, b ,c", []string{"a", "b", "c"}, common.ValueTypeStringArray, "", "text/plain", false},
+		{"Test A Uint8Array JSON", "[0, 255]", []uint8{0, 255}, common.ValueTypeUint8Array, "", "application/json", false},
+		{"Test A Uint8Array out of range", "[0, 256]", nil, common.ValueTypeUint8Array, "", "application/json", true},
+		{"Test A Uint16Array text", "1,65535", []uint16{1, 65535}, common.ValueTypeUint16Array, "", "text/plain", false},
+		{"Test A Uint32Array JSON", "[4294967295]", []uint32{4294967295}, common.ValueTypeUint32Array, "", "application/json", false},
+		{"Test A Uint64Array JSON", "[6744073709551615]", []uint64{6744073709551615}, common.ValueTypeUint64Array, "", "application/json", false},
+		{"Test A Int8Array text", "-128,127", []int8{-128, 127}, common.ValueTypeInt8Array, "", "text/plain", false},
+		{"Test A Int8Array out of range", "-128,128", nil, common.ValueTypeInt8Array, "", "text/plain", true},
+		{"Test A Int16Array JSON", "[-2001, 2001]", []int16{-2001, 2001}, common.ValueTypeInt16Array, "", "application/json", false},
+		{"Test A Int32Array JSON", "[-32000, 32000]", []int32{-32000, 32000}, common.ValueTypeInt32Array, "", "application/json", false},
+		{"Test A Int64Array text", "214748364800", []int64{214748364800}, common.ValueTypeInt64Array, "", "text/plain", false},
+		{"Test A Float32Array JSON", "[1.5, -2.25]", []float32{1.5, -2.25}, common.ValueTypeFloat32Array, "", "application/json", false},
+		{"Test A Float64Array text", "0.1, 0.2", []float64{0.1, 0.2}, common.ValueTypeFloat64Array, "", "text/plain", false},
+		{"Test A Float64Array empty", "[]", []float64{}, common.ValueTypeFloat64Array, "", "application/json", false},
+		{"Test A Float64Array error", "[0.1, \"x\"]", nil, common.ValueTypeFloat64Array, "", "application/json", true},
+		{"Test A Float64Array nested error", "[[0.1]]", nil, common.ValueTypeFloat64Array, "", "application/json", true},
+		{"Test A Float64Array invalid JSON", "[0.1,", nil, common.ValueTypeFloat64Array, "", "application/json", true},
 	}
 
 	for _, currentTest := range tests {
@@ -132,3 +159,175 @@ func TestNewCommandValue(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessBatchRequest(t *testing.T) {
+	deviceName := "sensor01"
+	resources := map[string]models.DeviceResource{
+		"temperature": {Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}},
+		"humidity":    {Name: "humidity", Properties: models.ResourceProperties{ValueType: common.ValueTypeUint8}},
+		"status":      {Name: "status", Properties: models.ResourceProperties{ValueType: common.ValueTypeString}},
+		"config":      {Name: "config", Properties: models.ResourceProperties{ValueType: common.ValueTypeObject}},
+	}
+
+	tests := []struct {
+		Name           string
+		ContentType    string
+		Body           string
+		ExpectedStatus int
+		Accepted       []string
+		Rejected       []string
+	}{
+		{"All valid", common.ContentTypeJSON, `{"temperature": 21.5, "humidity": "40", "status": "ok", "config": {"a": 1}}`, http.StatusOK, []string{"config", "humidity", "status", "temperature"}, nil},
+		{"Partially valid", common.ContentTypeJSON, `{"temperature": 21.5, "humidity": "high", "unknown": 1}`, http.StatusMultiStatus, []string{"temperature"}, []string{"humidity", "unknown"}},
+		{"None valid", common.ContentTypeJSON, `{"humidity": -1, "status": null}`, http.StatusBadRequest, []string{}, []string{"humidity", "status"}},
+		{"Not an object", common.ContentTypeJSON, `[1, 2]`, http.StatusBadRequest, nil, nil},
+		{"Content type with charset", "application/json; charset=utf-8", `{"temperature": 21.5}`, http.StatusOK, []string{"temperature"}, nil},
+		{"Wrong content type", common.ContentTypeText, `{"temperature": 21.5}`, http.StatusBadRequest, nil, nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.Name, func(t *testing.T) {
+			asyncValues := make(chan *sdkModels.AsyncValues, 1)
+			service := &mocks.DeviceServiceSDK{}
+			service.On("LoggingClient").Return(logger.NewMockClient())
+			service.On("AsyncValuesChannel").Return(asyncValues)
+			service.On("GetDeviceByName", deviceName).Return(models.Device{Name: deviceName}, nil)
+			for name, resource := range resources {
+				service.On("DeviceResource", deviceName, name).Return(resource, true)
+			}
+			service.On("DeviceResource", deviceName, mock.Anything).Return(models.DeviceResource{}, false)
+			batchHandler := NewRestHandler(service, CustomConfig{})
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v3/resource/"+deviceName, strings.NewReader(testCase.Body))
+			request.Header.Set(common.ContentType, testCase.ContentType)
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.SetParamNames(common.DeviceName)
+			c.SetParamValues(deviceName)
+
+			err := batchHandler.processBatchRequest(c)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedStatus, recorder.Code)
+
+			if testCase.Accepted == nil {
+				return
+			}
+
+			var response BatchResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, testCase.Accepted, response.Accepted)
+			for _, name := range testCase.Rejected {
+				assert.Contains(t, response.Rejected, name)
+			}
+
+			if len(testCase.Accepted) > 0 {
+				values := <-asyncValues
+				assert.Equal(t, deviceName, values.DeviceName)
+				assert.Len(t, values.CommandValues, len(testCase.Accepted))
+			} else {
+				assert.Empty(t, asyncValues)
+			}
+		})
+	}
+}
+
+func TestProcessBulkRequest(t *testing.T) {
+	service := &mocks.DeviceServiceSDK{}
+	asyncValues := make(chan *sdkModels.AsyncValues, 2)
+	service.On("LoggingClient").Return(logger.NewMockClient())
+	service.On("AsyncValuesChannel").Return(asyncValues)
+	service.On("GetDeviceByName", "sensor01").Return(models.Device{Name: "sensor01"}, nil)
+	service.On("GetDeviceByName", "sensor02").Return(models.Device{Name: "sensor02"}, nil)
+	service.On("GetDeviceByName", mock.Anything).Return(models.Device{}, errors.New("not found"))
+	temperature := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	service.On("DeviceResource", mock.Anything, "temperature").Return(temperature, true)
+	service.On("DeviceResource", mock.Anything, mock.Anything).Return(models.DeviceResource{}, false)
+	bulkHandler := NewRestHandler(service, CustomConfig{})
+
+	body := `{"device": "sensor01", "resource": "temperature", "value": 21.5, "origin": 1700000000000000000}
+{"device": "sensor02", "resource": "temperature", "value": "22.5"}
+
+{"device": "sensor01", "resource": "temperature", "value": 23.5}
+{"device": "sensor03", "resource": "temperature", "value": 24.5}
+{"device": "sensor01", "resource": "humidity", "value": 40}
+not json
+`
+	request := httptest.NewRequest(http.MethodPost, "/api/v3/resource", strings.NewReader(body))
+	request.Header.Set(common.ContentType, "application/x-ndjson; charset=utf-8")
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(request, recorder)
+
+	err := bulkHandler.processBulkRequest(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusMultiStatus, recorder.Code)
+
+	var response BulkResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, 3, response.Accepted)
+	assert.Equal(t, 3, response.Rejected)
+	require.Len(t, response.Results, 6)
+	var failedLines []int
+	for _, result := range response.Results {
+		if result.Error != "" {
+			failedLines = append(failedLines, result.Line)
+		}
+	}
+	assert.Equal(t, []int{5, 6, 7}, failedLines)
+
+	values := <-asyncValues
+	assert.Equal(t, "sensor01", values.DeviceName)
+	require.Len(t, values.CommandValues, 2)
+	assert.Equal(t, int64(1700000000000000000), values.CommandValues[0].Origin)
+	values = <-asyncValues
+	assert.Equal(t, "sensor02", values.DeviceName)
+	assert.Len(t, values.CommandValues, 1)
+
+	for _, contentType := range []string{common.ContentTypeJSON, "application/x-ndjson-extra", "application/x-ndjson; charset"} {
+		request = httptest.NewRequest(http.MethodPost, "/api/v3/resource", strings.NewReader(body))
+		request.Header.Set(common.ContentType, contentType)
+		recorder = httptest.NewRecorder()
+		require.NoError(t, bulkHandler.processBulkRequest(echo.New().NewContext(request, recorder)))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, contentType)
+	}
+}
+
+func TestProcessAsyncRequestOverloaded(t *testing.T) {
+	deviceName := "sensor01"
+	resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+
+	// Nobody receives from the unbuffered channel, like a stalled SDK pipeline
+	asyncValues := make(chan *sdkModels.AsyncValues)
+	service := &mocks.DeviceServiceSDK{}
+	service.On("LoggingClient").Return(logger.NewMockClient())
+	service.On("AsyncValuesChannel").Return(asyncValues)
+	service.On("GetDeviceByName", deviceName).Return(models.Device{Name: deviceName}, nil)
+	service.On("DeviceResource", deviceName, resource.Name).Return(resource, true)
+	overloadedHandler := NewRestHandler(service, CustomConfig{AsyncValuesTimeout: "10ms", RetryAfter: "1500ms"})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/v3/resource/"+deviceName+"/"+resource.Name, strings.NewReader("21.5"))
+	request.Header.Set(common.ContentType, common.ContentTypeText)
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(request, recorder)
+	c.SetParamNames(common.DeviceName, common.ResourceName)
+	c.SetParamValues(deviceName, resource.Name)
+
+	err := overloadedHandler.processAsyncRequest(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "2", recorder.Header().Get(retryAfterHeader))
+	assert.Equal(t, int64(1), overloadedHandler.metrics.readingsDelayed.Count())
+	assert.Equal(t, int64(1), overloadedHandler.metrics.readingsDropped.Count())
+
+	// Without RetryAfter clients aren't told to retry right away
+	overloadedHandler = NewRestHandler(service, CustomConfig{AsyncValuesTimeout: "10ms"})
+	request = httptest.NewRequest(http.MethodPost, "/api/v3/resource/"+deviceName+"/"+resource.Name, strings.NewReader("21.5"))
+	request.Header.Set(common.ContentType, common.ContentTypeText)
+	recorder = httptest.NewRecorder()
+	c = echo.New().NewContext(request, recorder)
+	c.SetParamNames(common.DeviceName, common.ResourceName)
+	c.SetParamValues(deviceName, resource.Name)
+
+	require.NoError(t, overloadedHandler.processAsyncRequest(c))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.NotContains(t, recorder.Header(), retryAfterHeader)
+}
@@ -0,0 +1,372 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces"
+
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/spf13/cast"
+)
+
+const (
+	// Network errors which can be selected as retryable
+	retryErrorTimeout           = "Timeout"
+	retryErrorConnectionRefused = "ConnectionRefused"
+	retryErrorConnectionReset   = "ConnectionReset"
+	retryErrorEOF               = "EOF"
+	retryErrorDNS               = "DNS"
+
+	commandRetriesName = "CommandRetries"
+
+	// maxRetryBackoff limits the delay between retries when BackoffCap isn't set
+	maxRetryBackoff = time.Hour
+)
+
+var retryErrors = []string{retryErrorTimeout, retryErrorConnectionRefused, retryErrorConnectionReset, retryErrorEOF, retryErrorDNS}
+
+// commandMetrics counts the retries of the commands sent to the end devices
+type commandMetrics struct {
+	commandRetries gometrics.Counter
+}
+
+func newCommandMetrics() *commandMetrics {
+	return &commandMetrics{commandRetries: gometrics.NewCounter()}
+}
+
+// register registers the counters with the SDK's metrics manager so they are
+// reported when enabled in the Writable.Telemetry configuration
+func (m *commandMetrics) register(sdk interfaces.DeviceServiceSDK) error {
+	metricsManager := sdk.MetricsManager()
+	if metricsManager == nil {
+		return errors.New("metrics manager not available")
+	}
+
+	if err := metricsManager.Register(commandRetriesName, m.commandRetries, nil); err != nil {
+		return fmt.Errorf("unable to register metric %s: %s", commandRetriesName, err.Error())
+	}
+
+	return nil
+}
+
+// RetryConfig holds the retry policy of the commands sent to the end devices. The
+// same settings can be overridden per device by protocol properties.
+type RetryConfig struct {
+	// MaxAttempts is the number of attempts including the first one, 0 or 1 disables retries
+	MaxAttempts int
+	// BackoffBase is the delay before the first retry, doubled for every further retry
+	BackoffBase string
+	// BackoffCap limits the delay between retries. Empty or zero means one hour
+	BackoffCap string
+	// Jitter is the fraction of the delay, between 0 and 1, which is randomly subtracted
+	// so that devices recovering at the same time aren't retried all at once
+	Jitter float64
+	// RetryableStatusCodes are the response status codes which are retried
+	RetryableStatusCodes []int
+	// RetryableErrors are the network errors which are retried: Timeout, ConnectionRefused,
+	// ConnectionReset, EOF and DNS
+	RetryableErrors []string
+	// RetryPUT allows retrying PUT requests, only safe when the device handles them
+	// idempotently. Other methods than GET and PUT, such as POST actions, are never retried
+	RetryPUT bool
+}
+
+// Validate ensures the retry configuration has proper values
+func (c RetryConfig) Validate() error {
+	if c.MaxAttempts < 0 {
+		return fmt.Errorf("invalid %s: must not be negative", RetryMaxAttempts)
+	}
+	if _, err := parseDuration(c.BackoffBase); err != nil {
+		return fmt.Errorf("invalid %s: %s", RetryBackoffBase, err.Error())
+	}
+	if _, err := parseDuration(c.BackoffCap); err != nil {
+		return fmt.Errorf("invalid %s: %s", RetryBackoffCap, err.Error())
+	}
+	if c.Jitter < 0 || c.Jitter > 1 {
+		return fmt.Errorf("invalid %s: must be between 0 and 1", RetryJitter)
+	}
+	for _, statusCode := range c.RetryableStatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return fmt.Errorf("invalid %s: %d is not a status code", RetryableStatusCodes, statusCode)
+		}
+	}
+	for _, name := range c.RetryableErrors {
+		if !slices.Contains(retryErrors, name) {
+			return fmt.Errorf("invalid %s: '%s' must be one of %s", RetryableErrors, name, strings.Join(retryErrors, ", "))
+		}
+	}
+
+	return nil
+}
+
+// retryOverrides holds the retry settings a device overrides, nil for the settings
+// taken from the service's configuration
+type retryOverrides struct {
+	maxAttempts          *int
+	backoffBase          *string
+	backoffCap           *string
+	jitter               *float64
+	retryableStatusCodes []int
+	retryableErrors      []string
+	retryPUT             *bool
+}
+
+// merge returns the retry policy with the overrides of the device applied
+func (c RetryConfig) merge(overrides retryOverrides) retryPolicy {
+	if overrides.maxAttempts != nil {
+		c.MaxAttempts = *overrides.maxAttempts
+	}
+	if overrides.backoffBase != nil {
+		c.BackoffBase = *overrides.backoffBase
+	}
+	if overrides.backoffCap != nil {
+		c.BackoffCap = *overrides.backoffCap
+	}
+	if overrides.jitter != nil {
+		c.Jitter = *overrides.jitter
+	}
+	if overrides.retryableStatusCodes != nil {
+		c.RetryableStatusCodes = overrides.retryableStatusCodes
+	}
+	if overrides.retryableErrors != nil {
+		c.RetryableErrors = overrides.retryableErrors
+	}
+	if overrides.retryPUT != nil {
+		c.RetryPUT = *overrides.retryPUT
+	}
+
+	// The values are validated when loading the configuration and the device
+	policy := retryPolicy{
+		maxAttempts:          max(c.MaxAttempts, 1),
+		jitter:               c.Jitter,
+		retryableStatusCodes: c.RetryableStatusCodes,
+		retryableErrors:      c.RetryableErrors,
+		retryPUT:             c.RetryPUT,
+	}
+	policy.backoffBase, _ = parseDuration(c.BackoffBase)
+	policy.backoffCap, _ = parseDuration(c.BackoffCap)
+
+	return policy
+}
+
+// parseRetryOverrides reads the retry settings a device overrides with its protocol
+// properties. Lists are comma separated.
+func parseRetryOverrides(protocolParams map[string]any) (retryOverrides, error) {
+	var overrides retryOverrides
+	var config RetryConfig
+
+	if property, ok := protocolParams[RetryMaxAttempts]; ok {
+		maxAttempts, err := cast.ToIntE(property)
+		if err != nil {
+			return overrides, fmt.Errorf("%s is not int type", RetryMaxAttempts)
+		}
+		config.MaxAttempts = maxAttempts
+		overrides.maxAttempts = &maxAttempts
+	}
+	if property, ok := protocolParams[RetryBackoffBase]; ok {
+		backoffBase, err := cast.ToStringE(property)
+		if err != nil {
+			return overrides, fmt.Errorf("%s is not string type", RetryBackoffBase)
+		}
+		config.BackoffBase = backoffBase
+		overrides.backoffBase = &backoffBase
+	}
+	if property, ok := protocolParams[RetryBackoffCap]; ok {
+		backoffCap, err := cast.ToStringE(property)
+		if err != nil {
+			return overrides, fmt.Errorf("%s is not string type", RetryBackoffCap)
+		}
+		config.BackoffCap = backoffCap
+		overrides.backoffCap = &backoffCap
+	}
+	if property, ok := protocolParams[RetryJitter]; ok {
+		jitter, err := cast.ToFloat64E(property)
+		if err != nil {
+			return overrides, fmt.Errorf("%s is not float type", RetryJitter)
+		}
+		config.Jitter = jitter
+		overrides.jitter = &jitter
+	}
+	if property, ok := protocolParams[RetryableStatusCodes]; ok {
+		overrides.retryableStatusCodes = []int{}
+		for _, value := range splitList(fmt.Sprint(property)) {
+			statusCode, err := strconv.Atoi(value)
+			if err != nil {
+				return overrides, fmt.Errorf("invalid %s: '%s' is not a status code", RetryableStatusCodes, value)
+			}
+			overrides.retryableStatusCodes = append(overrides.retryableStatusCodes, statusCode)
+		}
+		config.RetryableStatusCodes = overrides.retryableStatusCodes
+	}
+	if property, ok := protocolParams[RetryableErrors]; ok {
+		overrides.retryableErrors = splitList(fmt.Sprint(property))
+		config.RetryableErrors = overrides.retryableErrors
+	}
+	if property, ok := protocolParams[RetryPUT]; ok {
+		retryPUT, err := cast.ToBoolE(property)
+		if err != nil {
+			return overrides, fmt.Errorf("%s is not bool type", RetryPUT)
+		}
+		overrides.retryPUT = &retryPUT
+	}
+
+	// Validate the overridden values the same way as the service's configuration
+	if err := config.Validate(); err != nil {
+		return overrides, err
+	}
+
+	return overrides, nil
+}
+
+// splitList splits a comma separated protocol property, dropping empty entries
+func splitList(value string) []string {
+	list := []string{}
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// retryPolicy is the resolved retry policy of a device
+type retryPolicy struct {
+	maxAttempts          int
+	backoffBase          time.Duration
+	backoffCap           time.Duration
+	jitter               float64
+	retryableStatusCodes []int
+	retryableErrors      []string
+	retryPUT             bool
+}
+
+// attempts returns how often a request with the method may be sent
+func (p retryPolicy) attempts(method string) int {
+	switch {
+	case method == http.MethodGet:
+		return p.maxAttempts
+	case method == http.MethodPut && p.retryPUT:
+		return p.maxAttempts
+	default:
+		return 1
+	}
+}
+
+// backoff returns the delay before the given retry, starting with 1
+func (p retryPolicy) backoff(retry int) time.Duration {
+	limit := p.backoffCap
+	if limit == 0 {
+		limit = maxRetryBackoff
+	}
+	delay := p.backoffBase
+	for i := 1; i < retry && delay < limit; i++ {
+		// Stop doubling at the limit, so that many retries can't overflow the delay
+		if delay > limit/2 {
+			delay = limit
+			break
+		}
+		delay *= 2
+	}
+	delay = min(delay, limit)
+
+	return delay - time.Duration(float64(delay)*p.jitter*rand.Float64())
+}
+
+func (p retryPolicy) retryableStatusCode(statusCode int) bool {
+	return slices.Contains(p.retryableStatusCodes, statusCode)
+}
+
+// retryableError checks whether the error of sending a request is one of the
+// retryable network errors
+func (p retryPolicy) retryableError(err error) bool {
+	for _, name := range p.retryableErrors {
+		if networkErrorIs(err, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func networkErrorIs(err error, name string) bool {
+	switch name {
+	case retryErrorTimeout:
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	case retryErrorConnectionRefused:
+		return errors.Is(err, syscall.ECONNREFUSED)
+	case retryErrorConnectionReset:
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
+	case retryErrorEOF:
+		return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	case retryErrorDNS:
+		var dnsErr *net.DNSError
+		return errors.As(err, &dnsErr)
+	default:
+		return false
+	}
+}
+
+// doRequest sends the request to the device, retrying it according to the device's
+// retry policy. The response of the last attempt is returned.
+func (driver *RestDriver) doRequest(deviceName string, client *http.Client, request *http.Request, policy retryPolicy) (*http.Response, error) {
+	attempts := policy.attempts(request.Method)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(request)
+
+		var reason string
+		switch {
+		case err != nil && policy.retryableError(err):
+			reason = err.Error()
+		case err == nil && policy.retryableStatusCode(resp.StatusCode):
+			reason = fmt.Sprintf("status code %d", resp.StatusCode)
+		default:
+			return resp, err
+		}
+		if attempt >= attempts {
+			return resp, err
+		}
+
+		if resp != nil {
+			// Drain the body so that the connection can be reused for the retry
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedResponseSize))
+			_ = resp.Body.Close()
+		}
+		if request.GetBody != nil {
+			if request.Body, err = request.GetBody(); err != nil {
+				return nil, fmt.Errorf("unable to reset request body for retry: %v", err)
+			}
+		}
+
+		delay := policy.backoff(attempt)
+		driver.logger.Warnf("%s request to device '%s' failed with %s, retrying in %v (attempt %d of %d)",
+			request.Method, deviceName, reason, delay, attempt+1, attempts)
+		driver.metrics.commandRetries.Inc(1)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		}
+	}
+}
@@ -0,0 +1,169 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyServer fails the given number of requests before succeeding, failing
+// requests get the status code or, when zero, their connection is reset
+func flakyServer(t *testing.T, failures int32, statusCode int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			if statusCode != 0 {
+				w.WriteHeader(statusCode)
+				return
+			}
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			_ = conn.Close()
+			return
+		}
+		_, _ = w.Write([]byte("21.5"))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestHandleReadCommandsRetry(t *testing.T) {
+	retry := RetryConfig{
+		MaxAttempts:          3,
+		BackoffBase:          "1ms",
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+		RetryableErrors:      []string{retryErrorEOF, retryErrorConnectionReset},
+	}
+	resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64}}
+
+	tests := []struct {
+		name             string
+		failures         int32
+		statusCode       int
+		properties       map[string]any
+		expectedRequests int32
+		expectedError    bool
+	}{
+		{"connection reset retried", 1, 0, nil, 2, false},
+		{"status code retried", 2, http.StatusServiceUnavailable, nil, 3, false},
+		{"attempts exhausted", 3, http.StatusServiceUnavailable, nil, 3, true},
+		{"status code not retryable", 1, http.StatusInternalServerError, nil, 1, true},
+		{"retries disabled by device", 1, 0, map[string]any{RetryMaxAttempts: "1"}, 1, true},
+		{"status codes overridden by device", 1, http.StatusInternalServerError, map[string]any{RetryableStatusCodes: "500, 503"}, 2, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, requests := flakyServer(t, testCase.failures, testCase.statusCode)
+			driver, _ := newTestDriver(t, CustomConfig{Retry: retry}, "device", resource)
+
+			responses, err := driver.HandleReadCommands("device", restProtocols(t, server, testCase.properties), reqs)
+			assert.Equal(t, testCase.expectedRequests, requests.Load())
+			assert.Equal(t, int64(testCase.expectedRequests-1), driver.metrics.commandRetries.Count())
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 21.5, responses[0].Value)
+		})
+	}
+}
+
+func TestHandleWriteCommandsRetry(t *testing.T) {
+	retry := RetryConfig{MaxAttempts: 3, BackoffBase: "1ms", RetryableStatusCodes: []int{http.StatusServiceUnavailable}}
+	resource := models.DeviceResource{Name: "setpoint", Properties: models.ResourceProperties{ValueType: common.ValueTypeString}}
+	reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeString}}
+
+	var bodies []string
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	driver, _ := newTestDriver(t, CustomConfig{Retry: retry}, "device", resource)
+	value, err := sdkModels.NewCommandValue(resource.Name, common.ValueTypeString, "on")
+	require.NoError(t, err)
+
+	// PUTs aren't retried unless the device's PUTs are declared safe to retry
+	err = driver.HandleWriteCommands("device", restProtocols(t, server, nil), reqs, []*sdkModels.CommandValue{value})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"on"}, bodies)
+
+	bodies = nil
+	err = driver.HandleWriteCommands("device", restProtocols(t, server, map[string]any{RetryPUT: "true"}), reqs, []*sdkModels.CommandValue{value})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"on", "on"}, bodies, "the body must be sent again with the retry")
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryConfig{BackoffBase: "100ms", BackoffCap: "1s"}.merge(retryOverrides{})
+	assert.Equal(t, 1, policy.maxAttempts)
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 800*time.Millisecond, policy.backoff(4))
+	assert.Equal(t, time.Second, policy.backoff(5))
+	assert.Equal(t, time.Second, policy.backoff(100))
+
+	// Without BackoffCap the delay still doesn't grow beyond the maximum
+	uncapped := RetryConfig{BackoffBase: "100ms"}.merge(retryOverrides{})
+	assert.Equal(t, 800*time.Millisecond, uncapped.backoff(4))
+	assert.Equal(t, maxRetryBackoff, uncapped.backoff(1000))
+	huge := RetryConfig{BackoffBase: "100ms", BackoffCap: "2000000h"}.merge(retryOverrides{})
+	assert.Equal(t, 2000000*time.Hour, huge.backoff(1000))
+
+	policy.jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.backoff(1)
+		assert.True(t, delay > 50*time.Millisecond && delay <= 100*time.Millisecond, delay)
+	}
+}
+
+func TestParseRetryOverrides(t *testing.T) {
+	overrides, err := parseRetryOverrides(map[string]any{RetryMaxAttempts: "5", RetryableErrors: "Timeout,EOF", RetryPUT: true})
+	require.NoError(t, err)
+	policy := RetryConfig{MaxAttempts: 2, Jitter: 0.1}.merge(overrides)
+	assert.Equal(t, 5, policy.maxAttempts)
+	assert.Equal(t, 0.1, policy.jitter)
+	assert.Equal(t, []string{retryErrorTimeout, retryErrorEOF}, policy.retryableErrors)
+	assert.True(t, policy.retryPUT)
+	assert.Equal(t, 5, policy.attempts(http.MethodGet))
+	assert.Equal(t, 5, policy.attempts(http.MethodPut))
+	assert.Equal(t, 1, policy.attempts(http.MethodPost), "POST must never be retried")
+	assert.Equal(t, 1, policy.attempts(http.MethodPatch))
+
+	invalid := []map[string]any{
+		{RetryMaxAttempts: "-1"},
+		{RetryBackoffBase: "soon"},
+		{RetryJitter: "2"},
+		{RetryableStatusCodes: "503,abc"},
+		{RetryableStatusCodes: "42"},
+		{RetryableErrors: "Gremlins"},
+		{RetryPUT: "maybe"},
+	}
+	for _, properties := range invalid {
+		_, err := parseRetryOverrides(properties)
+		assert.Error(t, err, properties)
+	}
+}
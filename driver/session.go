@@ -0,0 +1,280 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+)
+
+const (
+	// AuthMethodSession logs in to the device and sends the session cookie and CSRF
+	// token of the login with later requests
+	AuthMethodSession = "Session"
+
+	defaultLoginBody  = `{"username":{{json .Username}},"password":{{json .Password}}}`
+	defaultCSRFHeader = "X-CSRF-Token"
+
+	// maxLoginResponseSize bounds the login response read into memory
+	maxLoginResponseSize = 1 << 20
+)
+
+// loginBodyFuncs are the functions available to login body templates
+var loginBodyFuncs = template.FuncMap{
+	"json": func(value string) (string, error) {
+		quoted, err := json.Marshal(value)
+		return string(quoted), err
+	},
+	"urlquery": url.QueryEscape,
+}
+
+// loginBodyData is the data of login body templates
+type loginBodyData struct {
+	Username string
+	Password string
+	// Secret holds all values of the device's auth secret
+	Secret map[string]string
+}
+
+// deviceSession holds the session of a device obtained by logging in
+type deviceSession struct {
+	cookies []*http.Cookie
+	csrf    string
+	// expiry is zero when the session is used until it's rejected
+	expiry time.Time
+}
+
+func (s deviceSession) valid(now time.Time) bool {
+	return (len(s.cookies) > 0 || s.csrf != "") && (s.expiry.IsZero() || now.Before(s.expiry))
+}
+
+// cookieHeader returns the Cookie header of the session
+func (s deviceSession) cookieHeader() string {
+	request := http.Request{Header: http.Header{}}
+	for _, cookie := range s.cookies {
+		request.AddCookie(cookie)
+	}
+
+	return request.Header.Get("Cookie")
+}
+
+// sentWith checks whether the request was authenticated with the session, by the
+// cookies and CSRF token it sent
+func (s deviceSession) sentWith(request *http.Request, csrfHeader string) bool {
+	if s.cookieHeader() != request.Header.Get("Cookie") {
+		return false
+	}
+
+	return s.csrf == "" || s.csrf == request.Header.Get(csrfHeader)
+}
+
+type sessionEntry struct {
+	session deviceSession
+	// mutex is held while logging in, so that a device is only logged in once when
+	// several commands need the session at the same time
+	mutex sync.Mutex
+}
+
+// deviceSessions holds the session of each device
+type deviceSessions struct {
+	entries map[string]*sessionEntry
+	mutex   sync.Mutex
+}
+
+func newDeviceSessions() *deviceSessions {
+	return &deviceSessions{entries: map[string]*sessionEntry{}}
+}
+
+func (s *deviceSessions) entry(deviceName string) *sessionEntry {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, ok := s.entries[deviceName]
+	if !ok {
+		entry = &sessionEntry{}
+		s.entries[deviceName] = entry
+	}
+
+	return entry
+}
+
+func (s *deviceSessions) remove(deviceName string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.entries, deviceName)
+}
+
+// parseSessionSettings reads the login settings of the device from its protocol properties
+func parseSessionSettings(protocolParams map[string]any, params *RestProtocolParams) error {
+	loginURL, ok := protocolParams[LoginURL]
+	if !ok {
+		return fmt.Errorf("%s is required with %s %s", LoginURL, AuthMethod, AuthMethodSession)
+	}
+	params.loginURL = fmt.Sprint(loginURL)
+	if parsed, err := url.Parse(params.loginURL); err != nil || (!parsed.IsAbs() && !strings.HasPrefix(params.loginURL, "/")) {
+		return fmt.Errorf("invalid %s '%s', must be an absolute URL or path", LoginURL, params.loginURL)
+	}
+
+	params.loginBody = defaultLoginBody
+	if body, ok := protocolParams[LoginBody]; ok {
+		params.loginBody = fmt.Sprint(body)
+	}
+	if _, err := template.New(LoginBody).Funcs(loginBodyFuncs).Parse(params.loginBody); err != nil {
+		return fmt.Errorf("invalid %s: %s", LoginBody, err.Error())
+	}
+
+	params.loginContentType = common.ContentTypeJSON
+	if contentType, ok := protocolParams[LoginContentType]; ok {
+		params.loginContentType = fmt.Sprint(contentType)
+	}
+	if cookie, ok := protocolParams[SessionCookie]; ok {
+		params.sessionCookie = fmt.Sprint(cookie)
+	}
+	params.csrfHeader = defaultCSRFHeader
+	if header, ok := protocolParams[CSRFHeader]; ok {
+		params.csrfHeader = fmt.Sprint(header)
+	}
+	if field, ok := protocolParams[CSRFField]; ok {
+		params.csrfField = fmt.Sprint(field)
+	}
+	if lifetime, ok := protocolParams[SessionLifetime]; ok {
+		var err error
+		if params.sessionLifetime, err = parseDuration(fmt.Sprint(lifetime)); err != nil {
+			return fmt.Errorf("invalid %s: %s", SessionLifetime, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// authenticateSession adds the session cookies and CSRF token of the device to the
+// request, logging in first when the device has no valid session
+func (driver *RestDriver) authenticateSession(deviceName string, request *http.Request, protocolParams RestProtocolParams) error {
+	entry := driver.sessions.entry(deviceName)
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if !entry.session.valid(time.Now()) {
+		session, err := driver.login(deviceName, protocolParams)
+		if err != nil {
+			return err
+		}
+		entry.session = session
+	}
+
+	request.Header.Del("Cookie")
+	for _, cookie := range entry.session.cookies {
+		request.AddCookie(cookie)
+	}
+	if entry.session.csrf != "" {
+		request.Header.Set(protocolParams.csrfHeader, entry.session.csrf)
+	}
+
+	return nil
+}
+
+// invalidateSession drops the session of the device, unless it was already replaced
+// by another one than the session of the rejected request
+func (driver *RestDriver) invalidateSession(deviceName string, rejected *http.Request, protocolParams RestProtocolParams) {
+	entry := driver.sessions.entry(deviceName)
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if entry.session.sentWith(rejected, protocolParams.csrfHeader) {
+		entry.session = deviceSession{}
+	}
+}
+
+// login sends the credentials of the device's auth secret to its login URL and
+// returns the session of the response
+func (driver *RestDriver) login(deviceName string, protocolParams RestProtocolParams) (deviceSession, error) {
+	secrets, err := driver.authSecret(protocolParams)
+	if err != nil {
+		return deviceSession{}, err
+	}
+
+	var body bytes.Buffer
+	bodyTemplate, err := template.New(LoginBody).Funcs(loginBodyFuncs).Parse(protocolParams.loginBody)
+	if err != nil {
+		return deviceSession{}, fmt.Errorf("invalid %s: %s", LoginBody, err.Error())
+	}
+	data := loginBodyData{Username: secrets[secretKeyUsername], Password: secrets[secretKeyPassword], Secret: secrets}
+	if err := bodyTemplate.Execute(&body, data); err != nil {
+		return deviceSession{}, fmt.Errorf("unable to create login body: %s", err.Error())
+	}
+
+	loginURL := protocolParams.loginURL
+	if strings.HasPrefix(loginURL, "/") {
+		loginURL = protocolParams.baseURL() + loginURL
+	}
+	request, err := http.NewRequest(http.MethodPost, loginURL, &body)
+	if err != nil {
+		return deviceSession{}, fmt.Errorf("login request creation failed: %v", err)
+	}
+	request.Header.Set(common.ContentType, protocolParams.loginContentType)
+
+	client, err := driver.httpClient(deviceName, protocolParams)
+	if err != nil {
+		return deviceSession{}, err
+	}
+	// The cookies are set by the login response itself, which may be a redirect
+	loginClient := *client
+	loginClient.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	loggedInAt := time.Now()
+	resp, err := loginClient.Do(request)
+	if err != nil {
+		return deviceSession{}, fmt.Errorf("login request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return deviceSession{}, fmt.Errorf("login failed with status code: %v", resp.StatusCode)
+	}
+
+	session := deviceSession{}
+	for _, cookie := range resp.Cookies() {
+		if protocolParams.sessionCookie == "" || cookie.Name == protocolParams.sessionCookie {
+			session.cookies = append(session.cookies, cookie)
+		}
+	}
+	if protocolParams.sessionCookie != "" && len(session.cookies) == 0 {
+		return deviceSession{}, fmt.Errorf("login response has no '%s' cookie", protocolParams.sessionCookie)
+	}
+
+	if protocolParams.csrfField != "" {
+		responseBody, err := io.ReadAll(io.LimitReader(resp.Body, maxLoginResponseSize))
+		if err != nil {
+			return deviceSession{}, fmt.Errorf("unable to read login response: %v", err)
+		}
+		if session.csrf, err = selectJSONText(responseBody, protocolParams.csrfField); err != nil {
+			return deviceSession{}, fmt.Errorf("no CSRF token in login response: %s", err.Error())
+		}
+	} else {
+		session.csrf = resp.Header.Get(protocolParams.csrfHeader)
+	}
+
+	if !session.valid(loggedInAt) {
+		return deviceSession{}, errors.New("login response has neither session cookie nor CSRF token")
+	}
+	if protocolParams.sessionLifetime > 0 {
+		session.expiry = loggedInAt.Add(protocolParams.sessionLifetime)
+	}
+	driver.logger.Debugf("Logged in to device '%s'", deviceName)
+
+	return session, nil
+}
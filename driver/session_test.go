@@ -0,0 +1,154 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionAuth(t *testing.T) {
+	var mutex sync.Mutex
+	var logins int
+	var rejectWith int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.URL.Path == "/api/login" {
+			var credentials struct{ User, Pass string }
+			if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil || credentials.User != "admin" || credentials.Pass != `s3"cr3t` {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			logins++
+			http.SetCookie(w, &http.Cookie{Name: "SESSIONID", Value: fmt.Sprintf("session-%d", logins)})
+			http.SetCookie(w, &http.Cookie{Name: "theme", Value: "dark"})
+			w.Header().Set(defaultCSRFHeader, fmt.Sprintf("csrf-%d", logins))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		cookie, err := r.Cookie("SESSIONID")
+		if err != nil || cookie.Value != fmt.Sprintf("session-%d", logins) || r.Header.Get(defaultCSRFHeader) != fmt.Sprintf("csrf-%d", logins) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if _, err := r.Cookie("theme"); err == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if rejectWith != 0 {
+			w.WriteHeader(rejectWith)
+			rejectWith = 0
+			logins++
+			return
+		}
+		_, _ = w.Write([]byte("21.5"))
+	}))
+	defer server.Close()
+
+	resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	driver, service := newTestDriver(t, CustomConfig{}, "device", resource)
+	secretProvider := &bootstrapMocks.SecretProvider{}
+	secretProvider.On("GetSecret", "device-auth").Return(map[string]string{secretKeyUsername: "admin", secretKeyPassword: `s3"cr3t`}, nil)
+	service.On("SecretProvider").Return(secretProvider)
+	protocols := restProtocols(t, server, map[string]any{
+		AuthMethod:     AuthMethodSession,
+		AuthSecretName: "device-auth",
+		LoginURL:       "/api/login",
+		LoginBody:      `{"user":{{json .Username}},"pass":{{json .Password}}}`,
+		SessionCookie:  "SESSIONID",
+	})
+	reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64}}
+	value, err := sdkModels.NewCommandValue(resource.Name, common.ValueTypeFloat64, 21.5)
+	require.NoError(t, err)
+
+	// The session of the login is reused
+	for i := 0; i < 2; i++ {
+		responses, err := driver.HandleReadCommands("device", protocols, reqs)
+		require.NoError(t, err)
+		assert.Equal(t, 21.5, responses[0].Value)
+	}
+	assert.Equal(t, 1, logins)
+
+	// A rejected session is renewed and the command sent once more
+	for _, statusCode := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		mutex.Lock()
+		rejectWith = statusCode
+		mutex.Unlock()
+		require.NoError(t, driver.HandleWriteCommands("device", protocols, reqs, []*sdkModels.CommandValue{value}))
+	}
+	assert.Equal(t, 5, logins, "each rejection invalidates the session on the device and logs in again")
+}
+
+func TestInvalidateSession(t *testing.T) {
+	driver, _ := newTestDriver(t, CustomConfig{}, "device")
+	protocolParams := RestProtocolParams{csrfHeader: defaultCSRFHeader}
+	rejected := func(cookie string, csrf string) *http.Request {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		if cookie != "" {
+			request.Header.Set("Cookie", cookie)
+		}
+		request.Header.Set(defaultCSRFHeader, csrf)
+		return request
+	}
+
+	tests := []struct {
+		name        string
+		session     deviceSession
+		rejected    *http.Request
+		invalidated bool
+	}{
+		{"same session", deviceSession{cookies: []*http.Cookie{{Name: "SESSIONID", Value: "session-1"}}, csrf: "csrf-1"}, rejected("SESSIONID=session-1", "csrf-1"), true},
+		{"replaced cookie", deviceSession{cookies: []*http.Cookie{{Name: "SESSIONID", Value: "session-2"}}, csrf: "csrf-2"}, rejected("SESSIONID=session-1", "csrf-1"), false},
+		{"same CSRF token", deviceSession{csrf: "csrf-1"}, rejected("", "csrf-1"), true},
+		{"replaced CSRF token", deviceSession{csrf: "csrf-2"}, rejected("", "csrf-1"), false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			driver.sessions.entry("device").session = testCase.session
+			driver.invalidateSession("device", testCase.rejected, protocolParams)
+			assert.Equal(t, testCase.invalidated, !driver.sessions.entry("device").session.valid(time.Now()))
+		})
+	}
+}
+
+func TestGetDeviceParametersSession(t *testing.T) {
+	protocols := map[string]models.ProtocolProperties{
+		RESTProtocol: {RESTHost: "localhost", RESTPort: "5000", RESTPath: "", AuthMethod: "Session", AuthSecretName: "device-auth"},
+	}
+	_, err := getDeviceParameters(protocols)
+	assert.Error(t, err, "the login URL is required")
+
+	protocols[RESTProtocol][LoginURL] = "/login"
+	params, err := getDeviceParameters(protocols)
+	require.NoError(t, err)
+	assert.Equal(t, defaultLoginBody, params.loginBody)
+	assert.Equal(t, defaultCSRFHeader, params.csrfHeader)
+
+	protocols[RESTProtocol][LoginBody] = "{{.Username"
+	_, err = getDeviceParameters(protocols)
+	assert.Error(t, err)
+
+	delete(protocols[RESTProtocol], LoginBody)
+	protocols[RESTProtocol][SessionLifetime] = "forever"
+	_, err = getDeviceParameters(protocols)
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+
+	// Keys of the device's TLS secret holding PEM encoded certificates and key
+	secretKeyCACert     = "caCert"
+	secretKeyClientCert = "clientCert"
+	secretKeyClientKey  = "clientKey"
+)
+
+// tlsMaterial holds the certificates and key of a device's TLS secret
+type tlsMaterial struct {
+	caCert     string
+	clientCert string
+	clientKey  string
+}
+
+// fingerprint identifies the material, so that clients are rebuilt once the secret
+// is rotated
+func (m tlsMaterial) fingerprint() string {
+	hash := sha256.New()
+	for _, value := range []string{m.caCert, m.clientCert, m.clientKey} {
+		hash.Write([]byte(value))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// deviceTLSMaterial reads the TLS material of the device from the secret store.
+// The secret store caches secrets, so reading it for every command is cheap and
+// picks up rotated certificates without restart.
+func (driver *RestDriver) deviceTLSMaterial(protocolParams RestProtocolParams) (tlsMaterial, error) {
+	if protocolParams.tlsSecretName == "" {
+		return tlsMaterial{}, nil
+	}
+
+	secrets, err := driver.sdk.SecretProvider().GetSecret(protocolParams.tlsSecretName)
+	if err != nil {
+		return tlsMaterial{}, fmt.Errorf("unable to get TLS secret '%s': %s", protocolParams.tlsSecretName, err.Error())
+	}
+
+	return tlsMaterial{
+		caCert:     secrets[secretKeyCACert],
+		clientCert: secrets[secretKeyClientCert],
+		clientKey:  secrets[secretKeyClientKey],
+	}, nil
+}
+
+// newTLSConfig creates the TLS configuration of a device from its settings and material
+func newTLSConfig(settings httpClientSettings, material tlsMaterial) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: settings.tlsServerName,
+		// Only when explicitly requested for the device by its protocol properties
+		InsecureSkipVerify: settings.tlsInsecureSkipVerify, //nolint:gosec
+	}
+
+	if material.caCert != "" {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM([]byte(material.caCert)) {
+			return nil, errors.New("no valid CA certificate found in TLS secret")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if material.clientCert != "" || material.clientKey != "" {
+		certificate, err := tls.X509KeyPair([]byte(material.clientCert), []byte(material.clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate in TLS secret: %s", err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
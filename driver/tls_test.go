@@ -0,0 +1,102 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleReadCommandsHTTPS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("21.5"))
+	}))
+	defer server.Close()
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	resource := models.DeviceResource{Name: "temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	driver, service := newTestDriver(t, CustomConfig{}, "device", resource)
+	secretProvider := &bootstrapMocks.SecretProvider{}
+	secretProvider.On("GetSecret", "device-tls").Return(map[string]string{secretKeyCACert: caCert}, nil)
+	secretProvider.On("GetSecret", "other-tls").Return(map[string]string{secretKeyCACert: "invalid"}, nil)
+	service.On("SecretProvider").Return(secretProvider)
+	reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64}}
+
+	tests := []struct {
+		name          string
+		properties    map[string]any
+		expectedError bool
+	}{
+		{"https with CA from secret", map[string]any{RESTScheme: "https", TLSSecretName: "device-tls"}, false},
+		{"https with unknown CA", map[string]any{RESTScheme: "https"}, true},
+		{"https skipping verification", map[string]any{RESTScheme: "HTTPS", TLSInsecureSkipVerify: "true"}, false},
+		{"https with invalid CA", map[string]any{RESTScheme: "https", TLSSecretName: "other-tls"}, true},
+		{"https with mismatching server name", map[string]any{RESTScheme: "https", TLSSecretName: "device-tls", TLSServerName: "device.local"}, true},
+		{"plain http to https server", nil, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			responses, err := driver.HandleReadCommands("device", restProtocols(t, server, testCase.properties), reqs)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 21.5, responses[0].Value)
+		})
+	}
+}
+
+func TestGetDeviceParametersScheme(t *testing.T) {
+	protocols := map[string]models.ProtocolProperties{
+		RESTProtocol: {RESTHost: "localhost", RESTPort: "5000", RESTPath: ""},
+	}
+	params, err := getDeviceParameters(protocols)
+	require.NoError(t, err)
+	assert.Equal(t, schemeHTTP, params.scheme)
+
+	protocols[RESTProtocol][RESTScheme] = "ftp"
+	_, err = getDeviceParameters(protocols)
+	assert.Error(t, err)
+
+	protocols[RESTProtocol][RESTScheme] = "https"
+	protocols[RESTProtocol][TLSInsecureSkipVerify] = "maybe"
+	_, err = getDeviceParameters(protocols)
+	assert.Error(t, err)
+}
+
+func TestClientPoolTLSRotation(t *testing.T) {
+	pool := newClientPool()
+	settings := HTTPClientConfig{}.merge(HTTPClientConfig{})
+
+	client, err := pool.client("device", settings, tlsMaterial{})
+	require.NoError(t, err)
+	same, err := pool.client("device", settings, tlsMaterial{})
+	require.NoError(t, err)
+	assert.Same(t, client, same)
+
+	_, err = pool.client("device", settings, tlsMaterial{caCert: "invalid"})
+	assert.Error(t, err)
+	_, err = pool.client("device", settings, tlsMaterial{clientCert: "invalid"})
+	assert.Error(t, err)
+
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	rotated, err := pool.client("device", settings, tlsMaterial{caCert: caCert})
+	require.NoError(t, err)
+	assert.NotSame(t, client, rotated, "client must be replaced when the TLS secret is rotated")
+}
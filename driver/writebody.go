@@ -0,0 +1,143 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+)
+
+// placeholderValueName is replaced by the written value in JSON body templates
+const placeholderValueName = "value"
+
+// writeBodyFuncs are the functions available to write body templates
+var writeBodyFuncs = template.FuncMap{
+	"json": func(value any) (string, error) {
+		data, err := marshalArrayValue(value)
+		return string(data), err
+	},
+	"urlquery": func(value any) string {
+		return url.QueryEscape(fmt.Sprint(value))
+	},
+	"xml": func(value any) (string, error) {
+		var escaped strings.Builder
+		err := xml.EscapeText(&escaped, []byte(fmt.Sprint(value)))
+		return escaped.String(), err
+	},
+}
+
+// writeBodyData is the data of write body templates
+type writeBodyData struct {
+	// Value is the written value of the command parameter
+	Value    any
+	Device   string
+	Resource string
+	// Properties are the REST protocol properties of the device
+	Properties map[string]any
+	// Attributes are the attributes of the resource and the command request
+	Attributes map[string]any
+}
+
+// renderWriteBody renders the write body template of the resource and returns the
+// body with its content type. String templates are Go templates of the writeBodyData,
+// object templates are JSON whose strings may hold {name} placeholders like URL path
+// templates. A string being just {value} is replaced by the value keeping its JSON
+// type, so numbers stay numbers. Without explicit content type the body is sent as
+// JSON if it is valid JSON.
+func renderWriteBody(bodyTemplate any, data writeBodyData, protocolParams RestProtocolParams) (string, string, error) {
+	var body string
+	switch bodyTemplate := bodyTemplate.(type) {
+	case string:
+		parsed, err := template.New(WriteBody).Funcs(writeBodyFuncs).Option("missingkey=error").Parse(bodyTemplate)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid %s: %s", WriteBody, err.Error())
+		}
+		var buf bytes.Buffer
+		if err := parsed.Execute(&buf, data); err != nil {
+			return "", "", fmt.Errorf("unable to render %s: %s", WriteBody, err.Error())
+		}
+		body = buf.String()
+	case map[string]any, []any:
+		expanded, err := expandJSONTemplate(bodyTemplate, data, protocolParams)
+		if err != nil {
+			return "", "", fmt.Errorf("unable to render %s: %s", WriteBody, err.Error())
+		}
+		buf, err := json.Marshal(expanded)
+		if err != nil {
+			return "", "", fmt.Errorf("unable to render %s: %v", WriteBody, err)
+		}
+		body = string(buf)
+	default:
+		return "", "", fmt.Errorf("invalid %s, must be a template string or a JSON object", WriteBody)
+	}
+
+	if contentType, ok := data.Attributes[WriteContentType]; ok {
+		return body, fmt.Sprint(contentType), nil
+	}
+	if json.Valid([]byte(body)) {
+		return body, common.ContentTypeJSON, nil
+	}
+
+	return body, common.ContentTypeText, nil
+}
+
+// expandJSONTemplate replaces the placeholders in the strings of a JSON body template
+func expandJSONTemplate(node any, data writeBodyData, protocolParams RestProtocolParams) (any, error) {
+	switch node := node.(type) {
+	case map[string]any:
+		expanded := make(map[string]any, len(node))
+		for key, value := range node {
+			var err error
+			if expanded[key], err = expandJSONTemplate(value, data, protocolParams); err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	case []any:
+		expanded := make([]any, len(node))
+		for i, value := range node {
+			var err error
+			if expanded[i], err = expandJSONTemplate(value, data, protocolParams); err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	case string:
+		if node == "{"+placeholderValueName+"}" {
+			// Marshal the value like array writes, so that byte arrays stay numbers
+			value, err := marshalArrayValue(data.Value)
+			return json.RawMessage(value), err
+		}
+
+		var missing []string
+		expanded := urlPlaceholder.ReplaceAllStringFunc(node, func(placeholder string) string {
+			name := placeholder[1 : len(placeholder)-1]
+			if name == placeholderValueName {
+				return fmt.Sprint(data.Value)
+			}
+			value, ok := placeholderValue(name, protocolParams, data.Resource, data.Attributes)
+			if !ok {
+				missing = append(missing, placeholder)
+				return placeholder
+			}
+			return fmt.Sprint(value)
+		})
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("'%s' has unresolved placeholders %s", node, strings.Join(missing, ", "))
+		}
+		return expanded, nil
+	default:
+		return node, nil
+	}
+}
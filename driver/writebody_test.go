@@ -0,0 +1,112 @@
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderWriteBody(t *testing.T) {
+	protocolParams := RestProtocolParams{properties: map[string]any{"unit": "C", "zone": "north"}}
+
+	tests := []struct {
+		name                string
+		template            any
+		value               any
+		attributes          map[string]any
+		expectedBody        string
+		expectedContentType string
+		expectedError       bool
+	}{
+		{"JSON template", map[string]any{"setpoint": map[string]any{"value": "{value}", "unit": "{unit}"}}, 21.5, nil,
+			`{"setpoint":{"unit":"C","value":21.5}}`, common.ContentTypeJSON, false},
+		{"JSON template embedded value", map[string]any{"command": "set {resourceName} to {value}"}, true, nil,
+			`{"command":"set setpoint to true"}`, common.ContentTypeJSON, false},
+		{"JSON template array", []any{"{value}", "{zone}"}, []uint8{1, 2}, nil,
+			`[[1,2],"north"]`, common.ContentTypeJSON, false},
+		{"JSON template attribute", map[string]any{"mode": "{mode}"}, 1, map[string]any{"mode": "eco"},
+			`{"mode":"eco"}`, common.ContentTypeJSON, false},
+		{"JSON template unresolved", map[string]any{"floor": "{floor}"}, 1, nil, "", "", true},
+		{"Go template JSON", `{"setpoint":{"value":{{.Value}},"unit":{{json .Properties.unit}}}}`, 21.5, nil,
+			`{"setpoint":{"value":21.5,"unit":"C"}}`, common.ContentTypeJSON, false},
+		{"Go template XML", `<setpoint zone="{{xml .Properties.zone}}">{{xml .Value}}</setpoint>`, "a<b", map[string]any{WriteContentType: "application/xml"},
+			`<setpoint zone="north">a&lt;b</setpoint>`, "application/xml", false},
+		{"Go template form", `{{.Resource}}={{urlquery .Value}}&device={{.Device}}`, "on off", nil,
+			"setpoint=on+off&device=thermostat", common.ContentTypeText, false},
+		{"Go template missing property", `{{.Properties.floor}}`, 1, nil, "", "", true},
+		{"Go template syntax error", `{{.Value`, 1, nil, "", "", true},
+		{"unsupported template", 42, 1, nil, "", "", true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			data := writeBodyData{
+				Value:      testCase.value,
+				Device:     "thermostat",
+				Resource:   "setpoint",
+				Properties: protocolParams.properties,
+				Attributes: testCase.attributes,
+			}
+			body, contentType, err := renderWriteBody(testCase.template, data, protocolParams)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedBody, body)
+			assert.Equal(t, testCase.expectedContentType, contentType)
+		})
+	}
+}
+
+func TestHandleWriteCommandsBodyTemplate(t *testing.T) {
+	var contentType string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get(common.ContentType)
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	resource := models.DeviceResource{Name: "setpoint", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat64}}
+	driver, _ := newTestDriver(t, CustomConfig{}, "thermostat", resource)
+	protocols := restProtocols(t, server, map[string]any{"unit": "C"})
+	value, err := sdkModels.NewCommandValue(resource.Name, common.ValueTypeFloat64, 21.5)
+	require.NoError(t, err)
+
+	attributes := map[string]any{WriteBody: map[string]any{"setpoint": map[string]any{"value": "{value}", "unit": "{unit}"}}}
+	reqs := []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64, Attributes: attributes}}
+	err = driver.HandleWriteCommands("thermostat", protocols, reqs, []*sdkModels.CommandValue{value})
+	require.NoError(t, err)
+	assert.Equal(t, common.ContentTypeJSON, contentType)
+	assert.JSONEq(t, `{"setpoint":{"value":21.5,"unit":"C"}}`, string(body))
+
+	body = nil
+	attributes = map[string]any{WriteBody: `{{.Properties.floor}}`}
+	reqs = []sdkModels.CommandRequest{{DeviceResourceName: resource.Name, Type: common.ValueTypeFloat64, Attributes: attributes}}
+	err = driver.HandleWriteCommands("thermostat", protocols, reqs, []*sdkModels.CommandValue{value})
+	assert.Error(t, err)
+	assert.Nil(t, body, "request must not be sent")
+
+	body = nil
+	level := models.DeviceResource{Name: "level", Properties: models.ResourceProperties{ValueType: common.ValueTypeInt8}}
+	driver, _ = newTestDriver(t, CustomConfig{}, "thermostat", level)
+	invalid := &sdkModels.CommandValue{DeviceResourceName: level.Name, Type: common.ValueTypeInt8, Value: "300"}
+	attributes = map[string]any{WriteBody: map[string]any{"level": "{value}"}}
+	reqs = []sdkModels.CommandRequest{{DeviceResourceName: level.Name, Type: common.ValueTypeInt8, Attributes: attributes}}
+	err = driver.HandleWriteCommands("thermostat", protocols, reqs, []*sdkModels.CommandValue{invalid})
+	assert.Error(t, err, "value out of range of the value type")
+	assert.Nil(t, body, "request must not be sent")
+}